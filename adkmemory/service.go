@@ -0,0 +1,244 @@
+// Package adkmemory provides a Google ADK memory.Service backed by mem0.
+//
+// Sessions are stored with the ADK user ID as the mem0 user_id and the ADK
+// app name as the mem0 app_id, so searches only see memories from the same
+// user within the same app:
+//
+//	client, _ := mem0.NewClient(apiKey)
+//	r, _ := runner.New(runner.Config{
+//	    AppName:        "support",
+//	    Agent:          agent,
+//	    SessionService: session.InMemoryService(),
+//	    MemoryService:  adkmemory.New(client),
+//	})
+package adkmemory
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/adk/memory"
+	"google.golang.org/adk/session"
+	"google.golang.org/genai"
+
+	mem0 "github.com/alcova-ai/mem0-go"
+)
+
+var _ memory.Service = (*Service)(nil)
+
+// ErrMissingUserID is returned by Search for a request without a user ID,
+// which would otherwise see every user's memories.
+var ErrMissingUserID = errors.New("adkmemory: user ID is required")
+
+// Service implements memory.Service on top of a mem0 client.
+type Service struct {
+	client  mem0.MemoryStore
+	agentID string
+	topK    int
+	infer   *bool
+
+	// sent tracks how many events of each recently added session have been
+	// sent, so a session added repeatedly during its lifetime only sends new
+	// events. Beyond maxSessions the least recently added are forgotten.
+	maxSessions int
+	mu          sync.Mutex
+	sent        map[string]*list.Element
+	lru         *list.List // front is most recently added
+}
+
+type sentEvents struct {
+	sessionID string
+	n         int
+}
+
+// Option configures a Service.
+type Option func(*Service)
+
+// WithAgentID stores memories with the given mem0 agent_id in addition to
+// the user and app scope.
+func WithAgentID(id string) Option {
+	return func(s *Service) { s.agentID = id }
+}
+
+// WithTopK sets the maximum number of memories returned by Search.
+func WithTopK(k int) Option {
+	return func(s *Service) { s.topK = k }
+}
+
+// WithInfer controls whether mem0 extracts facts from sessions (the
+// default) or stores each message verbatim.
+func WithInfer(infer bool) Option {
+	return func(s *Service) { s.infer = &infer }
+}
+
+// WithMaxSessions sets how many sessions the service remembers having sent,
+// 1000 by default. A forgotten session added again is sent in full.
+func WithMaxSessions(n int) Option {
+	return func(s *Service) { s.maxSessions = n }
+}
+
+// New returns a memory.Service that stores sessions in mem0.
+func New(client mem0.MemoryStore, opts ...Option) *Service {
+	s := &Service{
+		client:      client,
+		topK:        10,
+		maxSessions: 1000,
+		sent:        make(map[string]*list.Element),
+		lru:         list.New(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.maxSessions = max(s.maxSessions, 1)
+	return s
+}
+
+// AddSession sends the session's text events to mem0. Events already sent
+// by an earlier or concurrent call for the same session are skipped.
+func (s *Service) AddSession(ctx context.Context, sess session.Session) error {
+	events := sess.Events()
+	total := events.Len()
+	start := s.reserve(sess.ID(), total)
+
+	var messages []mem0.Message
+	var last time.Time
+	for i := start; i < total; i++ {
+		event := events.At(i)
+		if event == nil || event.Partial {
+			continue
+		}
+		msg, ok := toMessage(event.Content)
+		if !ok {
+			continue
+		}
+		messages = append(messages, msg)
+		last = event.Timestamp
+	}
+
+	if len(messages) > 0 {
+		req := &mem0.AddMemoriesRequest{
+			Messages: messages,
+			UserID:   sess.UserID(),
+			AppID:    sess.AppName(),
+			AgentID:  s.agentID,
+			RunID:    sess.ID(),
+			Infer:    s.infer,
+			Metadata: map[string]any{"session_id": sess.ID()},
+		}
+		if !last.IsZero() {
+			req.Timestamp = last.Unix()
+		}
+		if _, err := s.client.AddMemories(ctx, req); err != nil {
+			s.release(sess.ID(), start, total)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reserve marks the first total events of a session as sent and returns
+// how many were already marked, so concurrent calls never send the same
+// events twice.
+func (s *Service) reserve(sessionID string, total int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.sent[sessionID]; ok {
+		e := el.Value.(*sentEvents)
+		start := e.n
+		if start > total {
+			start = 0
+		}
+		e.n = total
+		s.lru.MoveToFront(el)
+		return start
+	}
+
+	s.sent[sessionID] = s.lru.PushFront(&sentEvents{sessionID: sessionID, n: total})
+	for s.lru.Len() > s.maxSessions {
+		el := s.lru.Back()
+		s.lru.Remove(el)
+		delete(s.sent, el.Value.(*sentEvents).sessionID)
+	}
+	return 0
+}
+
+// release undoes a reservation whose events failed to send, unless a later
+// call has reserved past it.
+func (s *Service) release(sessionID string, start, total int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.sent[sessionID]; ok {
+		if e := el.Value.(*sentEvents); e.n == total {
+			e.n = start
+		}
+	}
+}
+
+// Search returns the memories most relevant to the query for the request's
+// user and app. The request must name a user.
+func (s *Service) Search(ctx context.Context, req *memory.SearchRequest) (*memory.SearchResponse, error) {
+	if req.UserID == "" {
+		return nil, ErrMissingUserID
+	}
+	filters := mem0.NewFilters().WithUserID(req.UserID)
+	if req.AppName != "" {
+		filters.WithAppID(req.AppName)
+	}
+
+	resp, err := s.client.Search(ctx, &mem0.SearchRequest{
+		Query:   req.Query,
+		Filters: filters,
+		TopK:    s.topK,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]memory.Entry, 0, len(resp.Results))
+	for _, m := range resp.Results {
+		ts := m.UpdatedAt
+		if ts.IsZero() {
+			ts = m.CreatedAt
+		}
+		entries = append(entries, memory.Entry{
+			Content:   genai.NewContentFromText(m.Memory, genai.RoleUser),
+			Author:    "memory",
+			Timestamp: ts,
+		})
+	}
+
+	return &memory.SearchResponse{Memories: entries}, nil
+}
+
+// toMessage converts the text parts of content into a mem0 message. Model
+// turns become assistant messages; thoughts, function calls and other
+// non-text parts are dropped.
+func toMessage(content *genai.Content) (mem0.Message, bool) {
+	if content == nil {
+		return mem0.Message{}, false
+	}
+
+	var texts []string
+	for _, part := range content.Parts {
+		if part == nil || part.Thought || part.Text == "" {
+			continue
+		}
+		texts = append(texts, part.Text)
+	}
+	if len(texts) == 0 {
+		return mem0.Message{}, false
+	}
+
+	role := "user"
+	if content.Role == genai.RoleModel {
+		role = "assistant"
+	}
+	return mem0.Message{Role: role, Content: strings.Join(texts, "\n")}, true
+}
@@ -0,0 +1,231 @@
+package adkmemory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/adk/memory"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/session"
+	"google.golang.org/genai"
+
+	mem0 "github.com/alcova-ai/mem0-go"
+)
+
+type fakeSession struct {
+	id     string
+	events []*session.Event
+}
+
+func (s *fakeSession) ID() string                { return s.id }
+func (s *fakeSession) AppName() string           { return "support" }
+func (s *fakeSession) UserID() string            { return "user-1" }
+func (s *fakeSession) State() session.State      { return nil }
+func (s *fakeSession) Events() session.Events    { return s }
+func (s *fakeSession) LastUpdateTime() time.Time { return time.Time{} }
+func (s *fakeSession) Len() int                  { return len(s.events) }
+func (s *fakeSession) At(i int) *session.Event   { return s.events[i] }
+func (s *fakeSession) All() iter.Seq[*session.Event] {
+	return func(yield func(*session.Event) bool) {
+		for _, e := range s.events {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+func textEvent(role, text string) *session.Event {
+	return &session.Event{
+		LLMResponse: model.LLMResponse{Content: genai.NewContentFromText(text, genai.Role(role))},
+		Timestamp:   time.Unix(1700000000, 0),
+	}
+}
+
+func TestAddSession(t *testing.T) {
+	var got []mem0.AddMemoriesRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req mem0.AddMemoriesRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		got = append(got, req)
+		json.NewEncoder(w).Encode(mem0.AddMemoriesResponse{})
+	}))
+	defer server.Close()
+
+	client, _ := mem0.NewClient("test-key", mem0.WithBaseURL(server.URL))
+	svc := New(client)
+
+	sess := &fakeSession{id: "sess-1", events: []*session.Event{
+		textEvent(genai.RoleUser, "I'm vegetarian"),
+		{LLMResponse: model.LLMResponse{Content: &genai.Content{
+			Role:  genai.RoleModel,
+			Parts: []*genai.Part{{FunctionCall: &genai.FunctionCall{Name: "lookup"}}},
+		}}},
+		textEvent(genai.RoleModel, "Noted!"),
+	}}
+
+	if err := svc.AddSession(context.Background(), sess); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(got))
+	}
+	req := got[0]
+	if req.UserID != "user-1" || req.AppID != "support" || req.RunID != "sess-1" {
+		t.Errorf("unexpected scope: user %q app %q run %q", req.UserID, req.AppID, req.RunID)
+	}
+	want := []mem0.Message{{Role: "user", Content: "I'm vegetarian"}, {Role: "assistant", Content: "Noted!"}}
+	if len(req.Messages) != 2 || req.Messages[0] != want[0] || req.Messages[1] != want[1] {
+		t.Errorf("expected messages %v, got %v", want, req.Messages)
+	}
+
+	// Adding the same session again only sends new events.
+	sess.events = append(sess.events, textEvent(genai.RoleUser, "and allergic to nuts"))
+	if err := svc.AddSession(context.Background(), sess); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || len(got[1].Messages) != 1 || got[1].Messages[0].Content != "and allergic to nuts" {
+		t.Errorf("expected only the new event to be sent, got %+v", got)
+	}
+}
+
+func TestAddSessionConcurrent(t *testing.T) {
+	var requests atomic.Int32
+	received, unblock := make(chan struct{}), make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		received <- struct{}{}
+		<-unblock
+		json.NewEncoder(w).Encode(mem0.AddMemoriesResponse{})
+	}))
+	defer server.Close()
+
+	client, _ := mem0.NewClient("test-key", mem0.WithBaseURL(server.URL))
+	svc := New(client)
+	sess := &fakeSession{id: "sess-1", events: []*session.Event{textEvent(genai.RoleUser, "I'm vegetarian")}}
+
+	errs := make(chan error, 1)
+	go func() { errs <- svc.AddSession(context.Background(), sess) }()
+	<-received
+
+	// A second call while the first is sending has nothing new to send.
+	if err := svc.AddSession(context.Background(), sess); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	close(unblock)
+	if err := <-errs; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+}
+
+func TestAddSessionResendsAfterFailure(t *testing.T) {
+	var got []mem0.AddMemoriesRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req mem0.AddMemoriesRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		got = append(got, req)
+		if len(got) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(mem0.AddMemoriesResponse{})
+	}))
+	defer server.Close()
+
+	client, _ := mem0.NewClient("test-key", mem0.WithBaseURL(server.URL))
+	svc := New(client)
+	sess := &fakeSession{id: "sess-1", events: []*session.Event{textEvent(genai.RoleUser, "I'm vegetarian")}}
+
+	if err := svc.AddSession(context.Background(), sess); err == nil {
+		t.Fatal("expected error")
+	}
+	if err := svc.AddSession(context.Background(), sess); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || len(got[1].Messages) != 1 {
+		t.Errorf("expected the failed events to be sent again, got %+v", got)
+	}
+}
+
+func TestWithMaxSessions(t *testing.T) {
+	var got []mem0.AddMemoriesRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req mem0.AddMemoriesRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		got = append(got, req)
+		json.NewEncoder(w).Encode(mem0.AddMemoriesResponse{})
+	}))
+	defer server.Close()
+
+	client, _ := mem0.NewClient("test-key", mem0.WithBaseURL(server.URL))
+	svc := New(client, WithMaxSessions(1))
+	first := &fakeSession{id: "sess-1", events: []*session.Event{textEvent(genai.RoleUser, "I'm vegetarian")}}
+	second := &fakeSession{id: "sess-2", events: []*session.Event{textEvent(genai.RoleUser, "I live in Lisbon")}}
+
+	for _, sess := range []*fakeSession{first, second, first} {
+		if err := svc.AddSession(context.Background(), sess); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(svc.sent) != 1 {
+		t.Errorf("expected 1 tracked session, got %d", len(svc.sent))
+	}
+	if len(got) != 3 || got[2].RunID != "sess-1" {
+		t.Errorf("expected the forgotten session to be sent again, got %+v", got)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req mem0.SearchRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Filters["user_id"] != "user-1" || req.Filters["app_id"] != "support" {
+			t.Errorf("expected user and app scope, got %v", req.Filters)
+		}
+		json.NewEncoder(w).Encode([]mem0.Memory{{ID: "mem-1", Memory: "Is vegetarian"}})
+	}))
+	defer server.Close()
+
+	client, _ := mem0.NewClient("test-key", mem0.WithBaseURL(server.URL))
+	svc := New(client, WithTopK(5))
+
+	resp, err := svc.Search(context.Background(), &memory.SearchRequest{
+		Query:   "diet",
+		UserID:  "user-1",
+		AppName: "support",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Memories) != 1 {
+		t.Fatalf("expected 1 memory, got %d", len(resp.Memories))
+	}
+	if text := resp.Memories[0].Content.Parts[0].Text; text != "Is vegetarian" {
+		t.Errorf("expected 'Is vegetarian', got %q", text)
+	}
+}
+
+func TestSearchRequiresUserID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("expected no request, got %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client, _ := mem0.NewClient("test-key", mem0.WithBaseURL(server.URL))
+	svc := New(client)
+
+	_, err := svc.Search(context.Background(), &memory.SearchRequest{Query: "diet", AppName: "support"})
+	if !errors.Is(err, ErrMissingUserID) {
+		t.Errorf("expected ErrMissingUserID, got %v", err)
+	}
+}
@@ -0,0 +1,306 @@
+package anthropictools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/anthropics/anthropic-sdk-go"
+
+	mem0 "github.com/alcova-ai/mem0-go"
+)
+
+const (
+	defaultSearchLimit = 5
+	maxSearchLimit     = 20
+)
+
+// errNotInScope is reported to the model for memories outside the
+// dispatcher's scope. It reads as "not found" so the model cannot probe
+// for other users' memory IDs.
+var errNotInScope = errors.New("memory not found")
+
+// Scope fixes the mem0 identifiers every tool call is bound to. At least
+// one field must be set.
+type Scope struct {
+	UserID  string
+	AgentID string
+	AppID   string
+	RunID   string
+}
+
+func (s Scope) empty() bool {
+	return s.UserID == "" && s.AgentID == "" && s.AppID == "" && s.RunID == ""
+}
+
+func (s Scope) filters() mem0.Filters {
+	f := mem0.NewFilters()
+	if s.UserID != "" {
+		f.WithUserID(s.UserID)
+	}
+	if s.AgentID != "" {
+		f.WithAgentID(s.AgentID)
+	}
+	if s.AppID != "" {
+		f.WithAppID(s.AppID)
+	}
+	if s.RunID != "" {
+		f.WithRunID(s.RunID)
+	}
+	return f
+}
+
+func (s Scope) contains(m *mem0.Memory) bool {
+	return !s.empty() &&
+		(s.UserID == "" || m.UserID == s.UserID) &&
+		(s.AgentID == "" || m.AgentID == s.AgentID) &&
+		(s.AppID == "" || m.AppID == s.AppID) &&
+		(s.RunID == "" || m.RunID == s.RunID)
+}
+
+// Dispatcher executes memory tool calls against a mem0 client.
+type Dispatcher struct {
+	client mem0.MemoryStore
+	scope  Scope
+	infer  *bool
+}
+
+// Option configures a Dispatcher.
+type Option func(*Dispatcher)
+
+// WithInfer controls whether add_memory lets mem0 extract facts from the
+// content (the default) or stores it verbatim.
+func WithInfer(infer bool) Option {
+	return func(d *Dispatcher) { d.infer = &infer }
+}
+
+// NewDispatcher returns a Dispatcher whose tool calls are confined to scope.
+// It returns an error if scope sets no user, agent, app or run ID, since
+// an empty scope would give the model access to every memory.
+func NewDispatcher(client mem0.MemoryStore, scope Scope, opts ...Option) (*Dispatcher, error) {
+	if scope.empty() {
+		return nil, errors.New("mem0: dispatcher needs a user, agent, app or run ID")
+	}
+	d := &Dispatcher{client: client, scope: scope}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d, nil
+}
+
+// HandleMessage executes every tool_use block in msg and returns the
+// tool_result blocks to send back in the next user message. Blocks for
+// tools not defined by this package are skipped.
+func (d *Dispatcher) HandleMessage(ctx context.Context, msg *anthropic.Message) []anthropic.ContentBlockParamUnion {
+	var out []anthropic.ContentBlockParamUnion
+	for _, block := range msg.Content {
+		if block.Type != "tool_use" {
+			continue
+		}
+		use := block.AsToolUse()
+		if !IsMemoryTool(use.Name) {
+			continue
+		}
+		result := d.Handle(ctx, use)
+		out = append(out, anthropic.ContentBlockParamUnion{OfToolResult: &result})
+	}
+	return out
+}
+
+// IsMemoryTool reports whether name is one of the tools defined by this
+// package.
+func IsMemoryTool(name string) bool {
+	switch name {
+	case ToolSearchMemories, ToolAddMemory, ToolUpdateMemory, ToolDeleteMemory:
+		return true
+	}
+	return false
+}
+
+// Handle executes a single tool call. Failures, including mem0 API errors,
+// are returned as a tool result with is_error set so the model can react.
+func (d *Dispatcher) Handle(ctx context.Context, block anthropic.ToolUseBlock) anthropic.ToolResultBlockParam {
+	out, err := d.dispatch(ctx, block.Name, block.Input)
+	if err != nil {
+		return toolResult(block.ID, err.Error(), true)
+	}
+
+	data, err := json.Marshal(out)
+	if err != nil {
+		return toolResult(block.ID, err.Error(), true)
+	}
+	return toolResult(block.ID, string(data), false)
+}
+
+type searchInput struct {
+	Query string `json:"query"`
+	Limit int    `json:"limit"`
+}
+
+type addInput struct {
+	Content string `json:"content"`
+}
+
+type updateInput struct {
+	MemoryID string `json:"memory_id"`
+	Content  string `json:"content"`
+}
+
+type deleteInput struct {
+	MemoryID string `json:"memory_id"`
+}
+
+type memoryResult struct {
+	ID         string   `json:"id"`
+	Memory     string   `json:"memory"`
+	Score      float64  `json:"score,omitempty"`
+	Categories []string `json:"categories,omitempty"`
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, name string, input json.RawMessage) (any, error) {
+	switch name {
+	case ToolSearchMemories:
+		var in searchInput
+		if err := decodeInput(input, &in); err != nil {
+			return nil, err
+		}
+		return d.search(ctx, in)
+	case ToolAddMemory:
+		var in addInput
+		if err := decodeInput(input, &in); err != nil {
+			return nil, err
+		}
+		return d.add(ctx, in)
+	case ToolUpdateMemory:
+		var in updateInput
+		if err := decodeInput(input, &in); err != nil {
+			return nil, err
+		}
+		return d.update(ctx, in)
+	case ToolDeleteMemory:
+		var in deleteInput
+		if err := decodeInput(input, &in); err != nil {
+			return nil, err
+		}
+		return d.delete(ctx, in)
+	}
+	return nil, fmt.Errorf("unknown tool %q", name)
+}
+
+func (d *Dispatcher) search(ctx context.Context, in searchInput) (any, error) {
+	if in.Query == "" {
+		return nil, errors.New("query is required")
+	}
+	limit := in.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	limit = min(limit, maxSearchLimit)
+
+	resp, err := d.client.Search(ctx, &mem0.SearchRequest{
+		Query:   in.Query,
+		Filters: d.scope.filters(),
+		TopK:    limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]memoryResult, 0, len(resp.Results))
+	for _, m := range resp.Results {
+		results = append(results, memoryResult{
+			ID:         m.ID,
+			Memory:     m.Memory,
+			Score:      m.Score,
+			Categories: m.Categories,
+		})
+	}
+	return map[string]any{"memories": results}, nil
+}
+
+func (d *Dispatcher) add(ctx context.Context, in addInput) (any, error) {
+	if in.Content == "" {
+		return nil, errors.New("content is required")
+	}
+
+	resp, err := d.client.AddMemories(ctx, &mem0.AddMemoriesRequest{
+		Messages: []mem0.Message{{Role: "user", Content: in.Content}},
+		UserID:   d.scope.UserID,
+		AgentID:  d.scope.AgentID,
+		AppID:    d.scope.AppID,
+		RunID:    d.scope.RunID,
+		Infer:    d.infer,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"results": resp.Results}, nil
+}
+
+func (d *Dispatcher) update(ctx context.Context, in updateInput) (any, error) {
+	if in.Content == "" {
+		return nil, errors.New("content is required")
+	}
+	if err := d.checkScope(ctx, in.MemoryID); err != nil {
+		return nil, err
+	}
+
+	m, err := d.client.UpdateMemory(ctx, in.MemoryID, &mem0.UpdateMemoryRequest{Text: in.Content})
+	if err != nil {
+		return nil, err
+	}
+	return memoryResult{ID: m.ID, Memory: m.Memory}, nil
+}
+
+func (d *Dispatcher) delete(ctx context.Context, in deleteInput) (any, error) {
+	if err := d.checkScope(ctx, in.MemoryID); err != nil {
+		return nil, err
+	}
+
+	if err := d.client.DeleteMemory(ctx, in.MemoryID); err != nil {
+		return nil, err
+	}
+	return map[string]any{"deleted": in.MemoryID}, nil
+}
+
+// checkScope verifies that the memory exists and belongs to the
+// dispatcher's scope before it is modified.
+func (d *Dispatcher) checkScope(ctx context.Context, memoryID string) error {
+	if memoryID == "" {
+		return errors.New("memory_id is required")
+	}
+
+	m, err := d.client.GetMemory(ctx, memoryID)
+	if err != nil {
+		var apiErr *mem0.APIError
+		if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+			return errNotInScope
+		}
+		return err
+	}
+	if !d.scope.contains(m) {
+		return errNotInScope
+	}
+	return nil
+}
+
+func decodeInput(input json.RawMessage, v any) error {
+	if err := json.Unmarshal(input, v); err != nil {
+		return fmt.Errorf("invalid input: %w", err)
+	}
+	return nil
+}
+
+func toolResult(toolUseID, content string, isError bool) anthropic.ToolResultBlockParam {
+	result := anthropic.ToolResultBlockParam{
+		ToolUseID: toolUseID,
+		Content: []anthropic.ToolResultBlockParamContentUnion{
+			{OfText: &anthropic.TextBlockParam{Text: content}},
+		},
+	}
+	if isError {
+		result.IsError = anthropic.Bool(true)
+	}
+	return result
+}
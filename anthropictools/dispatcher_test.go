@@ -0,0 +1,201 @@
+package anthropictools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/anthropics/anthropic-sdk-go"
+
+	mem0 "github.com/alcova-ai/mem0-go"
+	"github.com/alcova-ai/mem0-go/mem0mock"
+)
+
+func newTestDispatcher(t *testing.T, handler http.HandlerFunc) *Dispatcher {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, _ := mem0.NewClient("test-key", mem0.WithBaseURL(server.URL))
+	d, err := NewDispatcher(client, Scope{UserID: "user-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return d
+}
+
+func resultText(r anthropic.ToolResultBlockParam) string {
+	if len(r.Content) == 0 || r.Content[0].OfText == nil {
+		return ""
+	}
+	return r.Content[0].OfText.Text
+}
+
+func TestSearchIgnoresModelScope(t *testing.T) {
+	d := newTestDispatcher(t, func(w http.ResponseWriter, r *http.Request) {
+		var req mem0.SearchRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Filters["user_id"] != "user-1" {
+			t.Errorf("expected fixed user scope, got %v", req.Filters)
+		}
+		if req.TopK != maxSearchLimit {
+			t.Errorf("expected limit clamped to %d, got %d", maxSearchLimit, req.TopK)
+		}
+		json.NewEncoder(w).Encode([]mem0.Memory{{ID: "mem-1", Memory: "Likes tea"}})
+	})
+
+	result := d.Handle(context.Background(), anthropic.ToolUseBlock{
+		ID:    "toolu_1",
+		Name:  ToolSearchMemories,
+		Input: json.RawMessage(`{"query":"drinks","limit":500,"user_id":"someone-else"}`),
+	})
+
+	if result.ToolUseID != "toolu_1" {
+		t.Errorf("expected tool_use_id 'toolu_1', got %q", result.ToolUseID)
+	}
+	if result.IsError.Valid() {
+		t.Fatalf("unexpected error result: %s", resultText(result))
+	}
+	if !strings.Contains(resultText(result), "Likes tea") {
+		t.Errorf("expected memory in result, got %s", resultText(result))
+	}
+}
+
+func TestDeleteOutOfScope(t *testing.T) {
+	deleted := false
+	d := newTestDispatcher(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(mem0.Memory{ID: "mem-9", UserID: "user-2"})
+		case http.MethodDelete:
+			deleted = true
+		}
+	})
+
+	result := d.Handle(context.Background(), anthropic.ToolUseBlock{
+		ID:    "toolu_2",
+		Name:  ToolDeleteMemory,
+		Input: json.RawMessage(`{"memory_id":"mem-9"}`),
+	})
+
+	if !result.IsError.Valid() || !result.IsError.Value {
+		t.Errorf("expected error result, got %s", resultText(result))
+	}
+	if resultText(result) != errNotInScope.Error() {
+		t.Errorf("expected %q, got %q", errNotInScope, resultText(result))
+	}
+	if deleted {
+		t.Error("memory outside scope was deleted")
+	}
+}
+
+func TestUpdateInScopeWithMock(t *testing.T) {
+	store := &mem0mock.MemoryStoreMock{
+		GetMemoryFunc: func(ctx context.Context, memoryID string) (*mem0.Memory, error) {
+			return &mem0.Memory{ID: memoryID, UserID: "user-1"}, nil
+		},
+		UpdateMemoryFunc: func(ctx context.Context, memoryID string, req *mem0.UpdateMemoryRequest) (*mem0.Memory, error) {
+			return &mem0.Memory{ID: memoryID, Memory: req.Text}, nil
+		},
+	}
+	d, _ := NewDispatcher(store, Scope{UserID: "user-1"})
+
+	result := d.Handle(context.Background(), anthropic.ToolUseBlock{
+		ID:    "toolu_4",
+		Name:  ToolUpdateMemory,
+		Input: json.RawMessage(`{"memory_id":"mem-1","content":"Prefers tea"}`),
+	})
+
+	if result.IsError.Valid() && result.IsError.Value {
+		t.Fatalf("unexpected error result: %s", resultText(result))
+	}
+	calls := store.UpdateMemoryCalls()
+	if len(calls) != 1 || calls[0].MemoryID != "mem-1" || calls[0].Req.Text != "Prefers tea" {
+		t.Errorf("expected one update of mem-1, got %+v", calls)
+	}
+}
+
+func TestNewDispatcherRequiresScope(t *testing.T) {
+	if _, err := NewDispatcher(&mem0mock.MemoryStoreMock{}, Scope{}); err == nil {
+		t.Error("expected error for empty scope")
+	}
+}
+
+func TestUpdateOutOfScopeWithMock(t *testing.T) {
+	store := &mem0mock.MemoryStoreMock{
+		GetMemoryFunc: func(ctx context.Context, memoryID string) (*mem0.Memory, error) {
+			// Same agent, but another user's memory.
+			return &mem0.Memory{ID: memoryID, UserID: "user-2", AgentID: "support"}, nil
+		},
+	}
+	d, _ := NewDispatcher(store, Scope{UserID: "user-1", AgentID: "support"})
+
+	result := d.Handle(context.Background(), anthropic.ToolUseBlock{
+		ID:    "toolu_5",
+		Name:  ToolUpdateMemory,
+		Input: json.RawMessage(`{"memory_id":"mem-9","content":"Prefers coffee"}`),
+	})
+
+	if !result.IsError.Valid() || !result.IsError.Value {
+		t.Errorf("expected error result, got %s", resultText(result))
+	}
+	if resultText(result) != errNotInScope.Error() {
+		t.Errorf("expected %q, got %q", errNotInScope, resultText(result))
+	}
+	if calls := store.UpdateMemoryCalls(); len(calls) != 0 {
+		t.Errorf("memory outside scope was updated: %+v", calls)
+	}
+}
+
+func TestAddUsesScope(t *testing.T) {
+	d := newTestDispatcher(t, func(w http.ResponseWriter, r *http.Request) {
+		var req mem0.AddMemoriesRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.UserID != "user-1" || req.Messages[0].Content != "Prefers window seats" {
+			t.Errorf("unexpected request: %+v", req)
+		}
+		json.NewEncoder(w).Encode(mem0.AddMemoriesResponse{Results: []mem0.AddEvent{{ID: "mem-2", Event: "ADD"}}})
+	})
+
+	result := d.Handle(context.Background(), anthropic.ToolUseBlock{
+		ID:    "toolu_3",
+		Name:  ToolAddMemory,
+		Input: json.RawMessage(`{"content":"Prefers window seats"}`),
+	})
+	if result.IsError.Valid() {
+		t.Fatalf("unexpected error result: %s", resultText(result))
+	}
+}
+
+func TestUnknownTool(t *testing.T) {
+	d := newTestDispatcher(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+
+	result := d.Handle(context.Background(), anthropic.ToolUseBlock{ID: "toolu_4", Name: "get_weather"})
+	if !result.IsError.Valid() {
+		t.Error("expected error result for unknown tool")
+	}
+}
+
+func TestToolsSchema(t *testing.T) {
+	tools := Tools()
+	if len(tools) != 4 {
+		t.Fatalf("expected 4 tools, got %d", len(tools))
+	}
+	for _, tool := range tools {
+		if !IsMemoryTool(tool.Name) {
+			t.Errorf("unexpected tool name %q", tool.Name)
+		}
+		data, err := json.Marshal(tool)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tool.Name, err)
+		}
+		if strings.Contains(string(data), "user_id") {
+			t.Errorf("%s: schema must not expose user_id: %s", tool.Name, data)
+		}
+	}
+}
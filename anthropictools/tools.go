@@ -0,0 +1,110 @@
+// Package anthropictools lets Claude read and write mem0 memories through
+// tool use.
+//
+// Pass Tools to the Messages API and hand each tool_use block back to a
+// Dispatcher. The dispatcher is bound to a fixed scope: the model cannot
+// choose the user or agent it reads or writes, and may only update or
+// delete memories within that scope.
+//
+//	d, err := anthropictools.NewDispatcher(client, anthropictools.Scope{UserID: "user-123"})
+//	msg, _ := ac.Messages.New(ctx, anthropic.MessageNewParams{
+//	    Model:     anthropic.ModelClaudeSonnet4_5,
+//	    MaxTokens: 1024,
+//	    Tools:     anthropictools.ToolUnions(),
+//	    Messages:  messages,
+//	})
+//	results := d.HandleMessage(ctx, msg)
+package anthropictools
+
+import (
+	"github.com/anthropics/anthropic-sdk-go"
+)
+
+// Tool names.
+const (
+	ToolSearchMemories = "search_memories"
+	ToolAddMemory      = "add_memory"
+	ToolUpdateMemory   = "update_memory"
+	ToolDeleteMemory   = "delete_memory"
+)
+
+// Tools returns the tool definitions for searching, adding, updating and
+// deleting memories.
+func Tools() []anthropic.ToolParam {
+	return []anthropic.ToolParam{
+		{
+			Name: ToolSearchMemories,
+			Description: anthropic.String("Search long-term memories about the user for facts relevant to a query. " +
+				"Use this before answering questions that may depend on the user's preferences, history or prior conversations."),
+			InputSchema: anthropic.ToolInputSchemaParam{
+				Properties: map[string]any{
+					"query": map[string]any{
+						"type":        "string",
+						"description": "Natural language description of what to look for.",
+					},
+					"limit": map[string]any{
+						"type":        "integer",
+						"description": "Maximum number of memories to return.",
+						"minimum":     1,
+						"maximum":     maxSearchLimit,
+					},
+				},
+				Required: []string{"query"},
+			},
+		},
+		{
+			Name: ToolAddMemory,
+			Description: anthropic.String("Store a new long-term memory about the user. " +
+				"Use this for durable facts and preferences worth remembering in future conversations, not for transient details."),
+			InputSchema: anthropic.ToolInputSchemaParam{
+				Properties: map[string]any{
+					"content": map[string]any{
+						"type":        "string",
+						"description": "The fact to remember, written as a standalone statement.",
+					},
+				},
+				Required: []string{"content"},
+			},
+		},
+		{
+			Name:        ToolUpdateMemory,
+			Description: anthropic.String("Replace the text of an existing memory, for example when a stored fact has changed. Use an id returned by search_memories."),
+			InputSchema: anthropic.ToolInputSchemaParam{
+				Properties: map[string]any{
+					"memory_id": map[string]any{
+						"type":        "string",
+						"description": "ID of the memory to update.",
+					},
+					"content": map[string]any{
+						"type":        "string",
+						"description": "The new text of the memory.",
+					},
+				},
+				Required: []string{"memory_id", "content"},
+			},
+		},
+		{
+			Name:        ToolDeleteMemory,
+			Description: anthropic.String("Delete a memory that is wrong or that the user asked to forget. Use an id returned by search_memories."),
+			InputSchema: anthropic.ToolInputSchemaParam{
+				Properties: map[string]any{
+					"memory_id": map[string]any{
+						"type":        "string",
+						"description": "ID of the memory to delete.",
+					},
+				},
+				Required: []string{"memory_id"},
+			},
+		},
+	}
+}
+
+// ToolUnions returns Tools wrapped for MessageNewParams.Tools.
+func ToolUnions() []anthropic.ToolUnionParam {
+	tools := Tools()
+	out := make([]anthropic.ToolUnionParam, len(tools))
+	for i := range tools {
+		out[i] = anthropic.ToolUnionParam{OfTool: &tools[i]}
+	}
+	return out
+}
@@ -0,0 +1,344 @@
+package mem0
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	ErrQueueFull    = errors.New("mem0: async writer queue is full")
+	ErrWriterClosed = errors.New("mem0: async writer is closed")
+)
+
+// BackpressurePolicy selects what Enqueue does when the queue is full.
+type BackpressurePolicy int
+
+const (
+	// BackpressureBlock waits for space or for the context to be done.
+	BackpressureBlock BackpressurePolicy = iota
+	// BackpressureDropOldest discards the oldest queued request to make
+	// room, reporting it to OnDrop.
+	BackpressureDropOldest
+	// BackpressureError returns ErrQueueFull.
+	BackpressureError
+)
+
+const (
+	defaultQueueSize    = 1000
+	defaultWriters      = 4
+	defaultWriteBackoff = 500 * time.Millisecond
+	maxWriteBackoff     = 30 * time.Second
+)
+
+// AsyncWriterOptions configures an AsyncWriter.
+type AsyncWriterOptions struct {
+	// SpoolPath is the file queued requests are logged to so they survive
+	// a restart. Empty keeps the queue in memory only.
+	SpoolPath string
+	// QueueSize bounds the number of requests waiting to be written.
+	// Defaults to 1000.
+	QueueSize int
+	// Workers is the number of concurrent AddMemories calls. Defaults to 4.
+	Workers int
+	// Backpressure applies when the queue is full. Defaults to
+	// BackpressureBlock.
+	Backpressure BackpressurePolicy
+
+	// Retry controls retries of failed writes. Nil retries transient
+	// failures until Close, backing off from 500ms to 30s, so requests
+	// wait out an outage in the queue.
+	Retry *RetryPolicy
+	// OnError is called with a request that failed permanently. The request
+	// is removed from the spool.
+	OnError func(req *AddMemoriesRequest, err error)
+	// OnDrop is called with a request discarded by BackpressureDropOldest.
+	OnDrop func(req *AddMemoriesRequest)
+}
+
+// AsyncWriterStats is a snapshot of an AsyncWriter's queue.
+type AsyncWriterStats struct {
+	// Depth is the number of requests waiting for a worker.
+	Depth int
+	// InFlight is the number of requests being written.
+	InFlight int
+
+	Enqueued uint64
+	Replayed uint64 // read back from the spool on start
+	Written  uint64
+	Failed   uint64
+	Dropped  uint64
+	Rejected uint64 // refused with ErrQueueFull
+}
+
+// AsyncWriter adds memories in the background so callers don't wait on
+// the API. Requests are queued in memory, logged to an append-only spool
+// file if one is configured, and written by a pool of workers. Requests
+// still unacknowledged when the process exits are replayed by the next
+// AsyncWriter opened on the same spool, so a request may be written twice
+// if the process dies mid-write.
+type AsyncWriter struct {
+	store  MemoryStore
+	opts   AsyncWriterOptions
+	spool  *spool
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu     sync.Mutex
+	queue  []spoolEntry
+	seq    uint64
+	closed bool
+	// adding counts requests being written to the spool outside mu. They
+	// hold a place in the queue and keep workers from exiting on Close.
+	adding int
+	// wake is closed and replaced whenever the queue changes, waking
+	// workers waiting for requests and enqueuers waiting for space.
+	wake  chan struct{}
+	stats AsyncWriterStats
+}
+
+// NewAsyncWriter starts an AsyncWriter that writes to store. If the spool
+// holds requests from a previous run they are queued first.
+func NewAsyncWriter(store MemoryStore, opts AsyncWriterOptions) (*AsyncWriter, error) {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = defaultQueueSize
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = defaultWriters
+	}
+	w := &AsyncWriter{store: store, opts: opts, wake: make(chan struct{})}
+
+	if opts.SpoolPath != "" {
+		s, pending, err := openSpool(opts.SpoolPath)
+		if err != nil {
+			return nil, err
+		}
+		w.spool = s
+		w.queue = pending
+		w.stats.Replayed = uint64(len(pending))
+		if len(pending) > 0 {
+			w.seq = pending[len(pending)-1].seq
+		}
+	}
+
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	for range opts.Workers {
+		w.wg.Add(1)
+		go w.work()
+	}
+	return w, nil
+}
+
+// Enqueue queues req to be added. It returns once the request is queued
+// (and fsynced to the spool), applying the backpressure policy if the queue
+// is full. The request must not be modified afterwards.
+func (w *AsyncWriter) Enqueue(ctx context.Context, req *AddMemoriesRequest) error {
+	if req == nil || len(req.Messages) == 0 {
+		return ErrEmptyRequest
+	}
+
+	w.mu.Lock()
+	var dropped []spoolEntry
+	for len(w.queue)+w.adding >= w.opts.QueueSize && !w.closed {
+		switch {
+		case w.opts.Backpressure == BackpressureError:
+			w.stats.Rejected++
+			w.mu.Unlock()
+			w.dropped(dropped)
+			return ErrQueueFull
+		case w.opts.Backpressure == BackpressureDropOldest && len(w.queue) > 0:
+			dropped = append(dropped, w.queue[0])
+			w.queue = w.queue[1:]
+			w.stats.Dropped++
+		default:
+			wake := w.wake
+			w.mu.Unlock()
+			select {
+			case <-wake:
+			case <-ctx.Done():
+				w.dropped(dropped)
+				return ctx.Err()
+			}
+			w.mu.Lock()
+		}
+	}
+	if w.closed {
+		w.mu.Unlock()
+		w.dropped(dropped)
+		return ErrWriterClosed
+	}
+
+	// Spool the request without holding mu, so a slow fsync doesn't stall
+	// other enqueuers, workers or Stats. It joins the queue once durable.
+	w.seq++
+	e := spoolEntry{seq: w.seq, req: req}
+	w.adding++
+	w.mu.Unlock()
+
+	w.dropped(dropped)
+	var err error
+	if w.spool != nil {
+		err = w.spool.add(e)
+	}
+
+	w.mu.Lock()
+	w.adding--
+	if err == nil {
+		w.queue = append(w.queue, e)
+		w.stats.Enqueued++
+	}
+	w.broadcast()
+	w.mu.Unlock()
+	return err
+}
+
+// dropped acknowledges requests discarded by BackpressureDropOldest and
+// reports them to OnDrop. w.mu must not be held.
+func (w *AsyncWriter) dropped(entries []spoolEntry) {
+	for _, e := range entries {
+		w.ack(e.seq)
+		if w.opts.OnDrop != nil {
+			w.opts.OnDrop(e.req)
+		}
+	}
+}
+
+// Stats returns a snapshot of the queue.
+func (w *AsyncWriter) Stats() AsyncWriterStats {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	s := w.stats
+	s.Depth = len(w.queue)
+	return s
+}
+
+// Close stops accepting requests and waits for the queue to drain. If ctx
+// is done first, in-flight writes are cancelled and the remaining
+// requests stay in the spool for the next run; Close then returns the
+// context's error.
+func (w *AsyncWriter) Close(ctx context.Context) error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	w.broadcast()
+	w.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		w.cancel()
+		<-done
+		err = ctx.Err()
+	}
+	w.cancel()
+
+	if w.spool != nil {
+		w.mu.Lock()
+		empty := len(w.queue) == 0 && w.stats.InFlight == 0 && w.adding == 0
+		w.mu.Unlock()
+		if cerr := w.spool.close(empty); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// broadcast wakes everything waiting on the queue. w.mu must be held.
+func (w *AsyncWriter) broadcast() {
+	close(w.wake)
+	w.wake = make(chan struct{})
+}
+
+// ack removes seq from the spool. A failed ack only means the request is
+// written again after a restart, so the error is dropped. w.mu must not be
+// held, since the spool is fsynced.
+func (w *AsyncWriter) ack(seq uint64) {
+	if w.spool != nil {
+		w.spool.ack(seq)
+	}
+}
+
+func (w *AsyncWriter) work() {
+	defer w.wg.Done()
+	for {
+		e, ok := w.next()
+		if !ok {
+			return
+		}
+		err := w.write(e.req)
+
+		abandoned := err != nil && w.ctx.Err() != nil
+		if !abandoned {
+			// Abandoned requests are left in the spool for the next run.
+			w.ack(e.seq)
+		}
+
+		w.mu.Lock()
+		w.stats.InFlight--
+		switch {
+		case err == nil:
+			w.stats.Written++
+		case !abandoned:
+			w.stats.Failed++
+		}
+		w.mu.Unlock()
+
+		if err != nil && !abandoned && w.opts.OnError != nil {
+			w.opts.OnError(e.req, err)
+		}
+	}
+}
+
+// next takes the oldest queued request, waiting for one if the queue is
+// empty. It returns false once the writer is closed and drained, including
+// requests still being spooled, or cancelled.
+func (w *AsyncWriter) next() (spoolEntry, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for len(w.queue) == 0 {
+		if w.closed && w.adding == 0 {
+			return spoolEntry{}, false
+		}
+		wake := w.wake
+		w.mu.Unlock()
+		select {
+		case <-wake:
+		case <-w.ctx.Done():
+		}
+		w.mu.Lock()
+		if w.ctx.Err() != nil {
+			return spoolEntry{}, false
+		}
+	}
+	if w.ctx.Err() != nil {
+		return spoolEntry{}, false
+	}
+
+	e := w.queue[0]
+	w.queue = w.queue[1:]
+	w.stats.InFlight++
+	w.broadcast()
+	return e, true
+}
+
+func (w *AsyncWriter) write(req *AddMemoriesRequest) error {
+	policy := RetryPolicy{InitialBackoff: defaultWriteBackoff, MaxBackoff: maxWriteBackoff}
+	limited := w.opts.Retry != nil
+	if limited {
+		policy = *w.opts.Retry
+	}
+
+	return addWithRetry(w.ctx, w.store, req, policy, limited)
+}
@@ -0,0 +1,242 @@
+package mem0
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// queueStore is a MemoryStore whose AddMemories records the first message
+// of each request. If gate is set each call waits for a value from it, and
+// if down is set each call fails with a 503.
+type queueStore struct {
+	MemoryStore
+
+	gate chan struct{}
+	down bool
+
+	mu    sync.Mutex
+	added []string
+}
+
+func (s *queueStore) AddMemories(ctx context.Context, req *AddMemoriesRequest) (*AddMemoriesResponse, error) {
+	if s.gate != nil {
+		select {
+		case <-s.gate:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if s.down {
+		return nil, &APIError{StatusCode: http.StatusServiceUnavailable}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.added = append(s.added, req.Messages[0].Content)
+	return &AddMemoriesResponse{}, nil
+}
+
+func addReq(text string) *AddMemoriesRequest {
+	return &AddMemoriesRequest{Messages: []Message{{Role: "user", Content: text}}, UserID: "u1"}
+}
+
+// waitInFlight waits until the writer has n requests in flight.
+func waitInFlight(t *testing.T, w *AsyncWriter, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for w.Stats().InFlight != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d in flight, got %+v", n, w.Stats())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestAsyncWriterDrainsOnClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spool.jsonl")
+	store := &queueStore{}
+	w, err := NewAsyncWriter(store, AsyncWriterOptions{SpoolPath: path, Workers: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	for _, text := range []string{"a", "b", "c", "d", "e"} {
+		if err := w.Enqueue(ctx, addReq(text)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := w.Close(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	slices.Sort(store.added)
+	if !slices.Equal(store.added, []string{"a", "b", "c", "d", "e"}) {
+		t.Errorf("expected all requests written, got %v", store.added)
+	}
+	if s := w.Stats(); s.Enqueued != 5 || s.Written != 5 || s.Depth != 0 || s.InFlight != 0 {
+		t.Errorf("unexpected stats: %+v", s)
+	}
+	if info, err := os.Stat(path); err != nil || info.Size() != 0 {
+		t.Errorf("expected empty spool after drain, got %v, %v", info, err)
+	}
+	if err := w.Enqueue(ctx, addReq("f")); err != ErrWriterClosed {
+		t.Errorf("expected ErrWriterClosed, got %v", err)
+	}
+}
+
+func TestAsyncWriterConcurrentEnqueue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spool.jsonl")
+	store := &queueStore{}
+	w, err := NewAsyncWriter(store, AsyncWriterOptions{SpoolPath: path, QueueSize: 4, Workers: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := w.Enqueue(ctx, addReq(fmt.Sprint(i))); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if err := w.Close(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if s := w.Stats(); len(store.added) != 50 || s.Written != 50 {
+		t.Errorf("expected 50 requests written, got %d and %+v", len(store.added), s)
+	}
+	if info, err := os.Stat(path); err != nil || info.Size() != 0 {
+		t.Errorf("expected empty spool after drain, got %v, %v", info, err)
+	}
+}
+
+func TestAsyncWriterBackpressure(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("error", func(t *testing.T) {
+		store := &queueStore{gate: make(chan struct{})}
+		w, _ := NewAsyncWriter(store, AsyncWriterOptions{QueueSize: 2, Workers: 1, Backpressure: BackpressureError})
+		defer w.Close(ctx)
+		defer close(store.gate)
+
+		w.Enqueue(ctx, addReq("a"))
+		waitInFlight(t, w, 1)
+		w.Enqueue(ctx, addReq("b"))
+		w.Enqueue(ctx, addReq("c"))
+		if err := w.Enqueue(ctx, addReq("d")); err != ErrQueueFull {
+			t.Errorf("expected ErrQueueFull, got %v", err)
+		}
+		if s := w.Stats(); s.Depth != 2 || s.Rejected != 1 {
+			t.Errorf("expected depth 2 and 1 rejected, got %+v", s)
+		}
+	})
+
+	t.Run("drop oldest", func(t *testing.T) {
+		store := &queueStore{gate: make(chan struct{})}
+		var dropped []string
+		w, _ := NewAsyncWriter(store, AsyncWriterOptions{
+			QueueSize:    2,
+			Workers:      1,
+			Backpressure: BackpressureDropOldest,
+			OnDrop:       func(req *AddMemoriesRequest) { dropped = append(dropped, req.Messages[0].Content) },
+		})
+
+		w.Enqueue(ctx, addReq("a"))
+		waitInFlight(t, w, 1)
+		w.Enqueue(ctx, addReq("b"))
+		w.Enqueue(ctx, addReq("c"))
+		if err := w.Enqueue(ctx, addReq("d")); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		close(store.gate)
+		w.Close(ctx)
+
+		if !slices.Equal(dropped, []string{"b"}) || !slices.Equal(store.added, []string{"a", "c", "d"}) {
+			t.Errorf("expected b dropped and a, c, d written; got %v and %v", dropped, store.added)
+		}
+	})
+
+	t.Run("block", func(t *testing.T) {
+		store := &queueStore{gate: make(chan struct{})}
+		w, _ := NewAsyncWriter(store, AsyncWriterOptions{QueueSize: 1, Workers: 1})
+		defer w.Close(ctx)
+		defer close(store.gate)
+
+		w.Enqueue(ctx, addReq("a"))
+		waitInFlight(t, w, 1)
+		w.Enqueue(ctx, addReq("b"))
+
+		blockCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		if err := w.Enqueue(blockCtx, addReq("c")); err != context.DeadlineExceeded {
+			t.Errorf("expected DeadlineExceeded, got %v", err)
+		}
+
+		done := make(chan error)
+		go func() { done <- w.Enqueue(ctx, addReq("c")) }()
+		store.gate <- struct{}{}
+		if err := <-done; err != nil {
+			t.Errorf("expected Enqueue to proceed once space frees, got %v", err)
+		}
+	})
+}
+
+func TestAsyncWriterReplaysSpool(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spool.jsonl")
+	ctx := context.Background()
+
+	down := &queueStore{down: true}
+	w, _ := NewAsyncWriter(down, AsyncWriterOptions{SpoolPath: path, Workers: 1})
+	w.Enqueue(ctx, addReq("a"))
+	w.Enqueue(ctx, addReq("b"))
+
+	closeCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if err := w.Close(closeCtx); err != context.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded while the store is down, got %v", err)
+	}
+
+	up := &queueStore{}
+	w, err := NewAsyncWriter(up, AsyncWriterOptions{SpoolPath: path, Workers: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s := w.Stats(); s.Replayed != 2 {
+		t.Errorf("expected 2 replayed requests, got %+v", s)
+	}
+	w.Enqueue(ctx, addReq("c"))
+	if err := w.Close(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(up.added, []string{"a", "b", "c"}) {
+		t.Errorf("expected a, b, c in order, got %v", up.added)
+	}
+}
+
+func TestAsyncWriterPermanentFailure(t *testing.T) {
+	store := &queueStore{down: true}
+	var failed []string
+	w, _ := NewAsyncWriter(store, AsyncWriterOptions{
+		Retry:   &RetryPolicy{MaxRetries: 1},
+		OnError: func(req *AddMemoriesRequest, err error) { failed = append(failed, req.Messages[0].Content) },
+	})
+	w.Enqueue(context.Background(), addReq("a"))
+	w.Close(context.Background())
+
+	if !slices.Equal(failed, []string{"a"}) || w.Stats().Failed != 1 {
+		t.Errorf("expected a reported as failed, got %v and %+v", failed, w.Stats())
+	}
+}
@@ -0,0 +1,77 @@
+package mem0
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	// defaultBatchSize is the platform's limit on items per batch request.
+	defaultBatchSize        = 1000
+	defaultBatchConcurrency = 4
+)
+
+// BatchFailure records a memory ID whose batch request failed.
+type BatchFailure struct {
+	ID  string
+	Err error
+}
+
+// BatchResult reports the outcome of BatchUpdate or BatchDelete per memory
+// ID, in request order. Large batches are sent in chunks, and the platform
+// applies each chunk all-or-nothing, so a failed chunk fails every ID in it.
+type BatchResult struct {
+	Succeeded []string
+	Failed    []BatchFailure
+}
+
+// Err returns nil if every ID succeeded, and otherwise an error wrapping
+// each distinct failure.
+func (r *BatchResult) Err() error {
+	if r == nil || len(r.Failed) == 0 {
+		return nil
+	}
+	var errs []error
+	seen := make(map[string]bool)
+	for _, f := range r.Failed {
+		if msg := f.Err.Error(); !seen[msg] {
+			seen[msg] = true
+			errs = append(errs, f.Err)
+		}
+	}
+	total := len(r.Succeeded) + len(r.Failed)
+	return fmt.Errorf("mem0: %d of %d batch items failed: %w", len(r.Failed), total, errors.Join(errs...))
+}
+
+// runBatch splits ids into chunks of at most the client's batch size and
+// calls send with each chunk's bounds, running up to the client's batch
+// concurrency at once. A failed chunk does not stop the others.
+func (c *Client) runBatch(ctx context.Context, ids []string, send func(ctx context.Context, lo, hi int) error) *BatchResult {
+	size := c.batchSize
+	if size <= 0 {
+		size = defaultBatchSize
+	}
+	concurrency := c.batchConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+
+	chunks := (len(ids) + size - 1) / size
+	errs := eachLimit(ctx, chunks, concurrency, func(ctx context.Context, i int) error {
+		return send(ctx, i*size, min((i+1)*size, len(ids)))
+	})
+
+	res := &BatchResult{}
+	for i, err := range errs {
+		lo, hi := i*size, min((i+1)*size, len(ids))
+		if err == nil {
+			res.Succeeded = append(res.Succeeded, ids[lo:hi]...)
+			continue
+		}
+		for _, id := range ids[lo:hi] {
+			res.Failed = append(res.Failed, BatchFailure{ID: id, Err: err})
+		}
+	}
+	return res
+}
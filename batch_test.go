@@ -0,0 +1,109 @@
+package mem0
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestBatchDeleteChunks(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		chunks [][]string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Memories []struct {
+				MemoryID string `json:"memory_id"`
+			} `json:"memories"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		var ids []string
+		for _, m := range body.Memories {
+			ids = append(ids, m.MemoryID)
+		}
+		mu.Lock()
+		chunks = append(chunks, ids)
+		mu.Unlock()
+
+		if slices.Contains(ids, "c") {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"detail":"Memory not found: c"}`))
+			return
+		}
+		w.Write([]byte(`{"message":"ok"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL), WithBatchSize(2), WithBatchConcurrency(2))
+	res, err := client.BatchDelete(context.Background(), &BatchDeleteRequest{MemoryIDs: []string{"a", "b", "c", "d", "e"}})
+
+	if len(chunks) != 3 {
+		t.Fatalf("expected 3 chunks, got %v", chunks)
+	}
+	for _, c := range chunks {
+		if len(c) > 2 {
+			t.Errorf("expected chunks of at most 2, got %v", c)
+		}
+	}
+
+	if !slices.Equal(res.Succeeded, []string{"a", "b", "e"}) {
+		t.Errorf("expected a, b, e to succeed, got %v", res.Succeeded)
+	}
+	if len(res.Failed) != 2 || res.Failed[0].ID != "c" || res.Failed[1].ID != "d" {
+		t.Errorf("expected c and d to fail, got %+v", res.Failed)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("expected error wrapping a 404 APIError, got %v", err)
+	}
+}
+
+func TestBatchUpdateSingleChunk(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var req BatchUpdateRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Memories) != 3 {
+			t.Errorf("expected 3 memories in one request, got %d", len(req.Memories))
+		}
+		w.Write([]byte(`{"message":"ok"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL))
+	res, err := client.BatchUpdate(context.Background(), &BatchUpdateRequest{
+		Memories: []BatchUpdateItem{{MemoryID: "a", Text: "x"}, {MemoryID: "b", Text: "y"}, {MemoryID: "c", Text: "z"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 1 || len(res.Succeeded) != 3 || len(res.Failed) != 0 {
+		t.Errorf("expected one request with 3 successes, got %d requests and %+v", requests, res)
+	}
+}
+
+func TestBatchDeleteRequestJSON(t *testing.T) {
+	data, err := json.Marshal(&BatchDeleteRequest{MemoryIDs: []string{"a", "b"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"memories":[{"memory_id":"a"},{"memory_id":"b"}]}`
+	if string(data) != want {
+		t.Errorf("expected %s, got %s", want, data)
+	}
+
+	var decoded BatchDeleteRequest
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(decoded.MemoryIDs) != 2 || decoded.MemoryIDs[0] != "a" || decoded.MemoryIDs[1] != "b" {
+		t.Errorf("expected round trip to a and b, got %v", decoded.MemoryIDs)
+	}
+}
@@ -6,9 +6,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -25,6 +29,23 @@ type Client struct {
 	userAgent  string
 	orgID      string
 	projectID  string
+	retry      *RetryPolicy
+	limiter    *RateLimiter
+	middleware []Middleware
+	handler    RoundTripFunc
+
+	logger    *slog.Logger
+	logLevel  slog.Level
+	logBodies bool
+
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	telemetry      *telemetry
+
+	cache *searchCache
+
+	batchSize        int
+	batchConcurrency int
 }
 
 // NewClient creates a new mem0 API client with the given API key.
@@ -41,16 +62,32 @@ func NewClient(apiKey string, opts ...ClientOption) (*Client, error) {
 			Timeout: defaultTimeout,
 		},
 		userAgent: defaultUserAgent,
+		logLevel:  slog.LevelDebug,
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
 
+	if c.tracerProvider != nil || c.meterProvider != nil {
+		c.telemetry = newTelemetry(c.tracerProvider, c.meterProvider)
+	}
+
+	c.handler = c.roundTrip
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		c.handler = c.middleware[i](c.handler)
+	}
+
 	return c, nil
 }
 
-func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+func (c *Client) do(ctx context.Context, op, method, path string, query url.Values, body, out any) (err error) {
+	if c.telemetry != nil {
+		var end func(out any, err error)
+		ctx, end = c.telemetry.start(ctx, op, method, path, body)
+		defer func() { end(out, err) }()
+	}
+
 	u, err := url.Parse(c.baseURL + path)
 	if err != nil {
 		return fmt.Errorf("mem0: invalid URL: %w", err)
@@ -60,16 +97,49 @@ func (c *Client) do(ctx context.Context, method, path string, query url.Values,
 		u.RawQuery = query.Encode()
 	}
 
-	var bodyReader io.Reader
+	var data []byte
 	if body != nil {
-		data, err := json.Marshal(body)
+		data, err = json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("mem0: failed to marshal request: %w", err)
 		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx, path); err != nil {
+				return fmt.Errorf("mem0: rate limit wait: %w", err)
+			}
+		}
+
+		err = c.attempt(ctx, attempt, op, method, u.String(), body, data, out)
+		if err == nil {
+			return nil
+		}
+
+		if c.retry == nil || !c.retry.allows(method) ||
+			attempt >= c.retry.MaxRetries || !c.retry.retryable(err) {
+			return err
+		}
+		wait := c.retry.backoff(attempt, err)
+		if c.logger != nil {
+			c.logRetry(ctx, op, attempt, wait, err)
+		}
+		if !sleepCtx(ctx, wait) {
+			return err
+		}
+	}
+}
+
+// attempt builds a fresh request from data, so the body can be replayed on
+// retries, and passes it through the middleware chain.
+func (c *Client) attempt(ctx context.Context, n int, op, method, rawURL string, body any, data []byte, out any) error {
+	var bodyReader io.Reader
+	if data != nil {
 		bodyReader = bytes.NewReader(data)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), bodyReader)
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, bodyReader)
 	if err != nil {
 		return fmt.Errorf("mem0: failed to create request: %w", err)
 	}
@@ -77,11 +147,31 @@ func (c *Client) do(ctx context.Context, method, path string, query url.Values,
 	req.Header.Set("Authorization", "Token "+c.apiKey)
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", c.userAgent)
-	if body != nil {
+	if data != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	resp, err := c.httpClient.Do(req)
+	call := &Call{
+		Operation:   op,
+		Request:     body,
+		Response:    out,
+		HTTPRequest: req,
+	}
+	start := time.Now()
+	err = c.handler(ctx, call)
+	if c.logger != nil {
+		c.logAttempt(ctx, n, call, time.Since(start), err)
+	}
+	if c.telemetry != nil {
+		c.telemetry.recordAttempt(ctx, n, call)
+	}
+	return err
+}
+
+// roundTrip is the innermost RoundTripFunc. It sends the request and
+// decodes the response into call.Response.
+func (c *Client) roundTrip(ctx context.Context, call *Call) error {
+	resp, err := c.httpClient.Do(call.HTTPRequest.WithContext(ctx))
 	if err != nil {
 		return fmt.Errorf("mem0: request failed: %w", err)
 	}
@@ -91,18 +181,21 @@ func (c *Client) do(ctx context.Context, method, path string, query url.Values,
 	if err != nil {
 		return fmt.Errorf("mem0: failed to read response: %w", err)
 	}
+	call.HTTPResponse = resp
+	call.ResponseBody = respBody
 
 	if resp.StatusCode >= 400 {
 		apiErr := &APIError{
 			StatusCode: resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
 			RawBody:    respBody,
 		}
 		_ = json.Unmarshal(respBody, apiErr)
 		return apiErr
 	}
 
-	if out != nil && len(respBody) > 0 {
-		if err := json.Unmarshal(respBody, out); err != nil {
+	if call.Response != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, call.Response); err != nil {
 			return fmt.Errorf("mem0: failed to unmarshal response: %w", err)
 		}
 	}
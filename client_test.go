@@ -288,12 +288,12 @@ func TestBatchValidation(t *testing.T) {
 		t.Errorf("BatchUpdate: expected ErrEmptyRequest for empty memories, got %v", err)
 	}
 
-	err = client.BatchDelete(context.Background(), nil)
+	_, err = client.BatchDelete(context.Background(), nil)
 	if err != ErrEmptyRequest {
 		t.Errorf("BatchDelete: expected ErrEmptyRequest for nil request, got %v", err)
 	}
 
-	err = client.BatchDelete(context.Background(), &BatchDeleteRequest{})
+	_, err = client.BatchDelete(context.Background(), &BatchDeleteRequest{})
 	if err != ErrEmptyRequest {
 		t.Errorf("BatchDelete: expected ErrEmptyRequest for empty IDs, got %v", err)
 	}
@@ -32,6 +32,11 @@
 //	combined := mem0.NewFilters().WithUserID("user-1").
 //	    Or(mem0.NewFilters().WithUserID("user-2"))
 //
+// For the full v2 grammar, build a typed [FilterExpr]:
+//
+//	expr := mem0.And(mem0.Eq("user_id", "user-1"), mem0.Metadata("tier").Eq("gold"))
+//	filters := expr.Filters()
+//
 // # Configuration
 //
 // The client supports various options:
@@ -1,7 +1,9 @@
 package mem0
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -20,6 +22,50 @@ type ListEntitiesResponse struct {
 	Page     int      `json:"page,omitempty"`
 	PageSize int      `json:"page_size,omitempty"`
 	Total    int      `json:"total,omitempty"`
+	Next     string   `json:"next,omitempty"` // URL of the next page, if any
+
+	paginated bool
+}
+
+// UnmarshalJSON accepts a bare array of entities as well as the paginated
+// envelope, which may report the total as "count".
+func (r *ListEntitiesResponse) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		return json.Unmarshal(data, &r.Results)
+	}
+
+	var env struct {
+		Results  []Entity `json:"results"`
+		Page     int      `json:"page"`
+		PageSize int      `json:"page_size"`
+		Total    int      `json:"total"`
+		Count    int      `json:"count"`
+		Next     *string  `json:"next"`
+	}
+	if err := json.Unmarshal(data, &env); err != nil {
+		return err
+	}
+
+	*r = ListEntitiesResponse{
+		Results:   env.Results,
+		Page:      env.Page,
+		PageSize:  env.PageSize,
+		Total:     env.Total,
+		paginated: true,
+	}
+	if r.Total == 0 {
+		r.Total = env.Count
+	}
+	if env.Next != nil {
+		r.Next = *env.Next
+	}
+	return nil
+}
+
+// hasMore reports whether a page after this one may exist.
+func (r *ListEntitiesResponse) hasMore() bool {
+	return hasMorePages(r.paginated, r.Next, r.Page, r.PageSize, r.Total, len(r.Results))
 }
 
 // ListEntities retrieves entities (users, agents, apps, runs) with optional filtering.
@@ -56,10 +102,19 @@ func (c *Client) ListEntities(ctx context.Context, req *ListEntitiesRequest) (*L
 	}
 
 	var resp ListEntitiesResponse
-	if err := c.do(ctx, http.MethodGet, "/v1/entities/", query, nil, &resp); err != nil {
+	if err := c.do(ctx, "ListEntities", http.MethodGet, "/v1/entities/", query, nil, &resp); err != nil {
 		return nil, err
 	}
 
+	if req != nil {
+		if resp.Page == 0 {
+			resp.Page = req.Page
+		}
+		if resp.PageSize == 0 {
+			resp.PageSize = req.PageSize
+		}
+	}
+
 	return &resp, nil
 }
 
@@ -80,7 +135,26 @@ func (c *Client) DeleteEntity(ctx context.Context, entityType EntityType, entity
 	}
 
 	path := "/v2/entities/" + url.PathEscape(string(entityType)) + "/" + url.PathEscape(entityID) + "/"
-	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+	if err := c.do(ctx, "DeleteEntity", http.MethodDelete, path, nil, nil, nil); err != nil {
+		return err
+	}
+
+	var w cacheScope
+	switch entityType {
+	case EntityTypeUser:
+		w.userID = entityID
+	case EntityTypeAgent:
+		w.agentID = entityID
+	}
+	c.invalidateMatching(w, func(m *Memory) bool {
+		return map[EntityType]string{
+			EntityTypeUser:  m.UserID,
+			EntityTypeAgent: m.AgentID,
+			EntityTypeApp:   m.AppID,
+			EntityTypeRun:   m.RunID,
+		}[entityType] == entityID
+	})
+	return nil
 }
 
 // DeleteUser deletes a user entity and all its associated memories.
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 var (
@@ -12,6 +13,7 @@ var (
 	ErrMissingID      = errors.New("mem0: id is required")
 	ErrMissingFilters = errors.New("mem0: filters are required")
 	ErrEmptyRequest   = errors.New("mem0: request cannot be empty")
+	ErrEventFailed    = errors.New("mem0: event failed")
 )
 
 type APIError struct {
@@ -21,6 +23,10 @@ type APIError struct {
 	Message    string `json:"message,omitempty"`
 	Detail     string `json:"detail,omitempty"`
 	RawBody    []byte `json:"-"`
+
+	// RetryAfter is the delay requested by the server's Retry-After header,
+	// or zero if none was sent.
+	RetryAfter time.Duration `json:"-"`
 }
 
 func (e *APIError) Error() string {
@@ -47,3 +53,12 @@ func (e *APIError) IsUnauthorized() bool {
 func (e *APIError) IsRateLimited() bool {
 	return e.StatusCode == http.StatusTooManyRequests
 }
+
+// IsRetryable reports whether the request may succeed if sent again: the
+// client was rate limited or the server failed transiently.
+func (e *APIError) IsRetryable() bool {
+	if e.IsRateLimited() {
+		return true
+	}
+	return e.StatusCode >= 500 && e.StatusCode != http.StatusNotImplemented
+}
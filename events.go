@@ -0,0 +1,145 @@
+package mem0
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// EventStatus is the processing state of an asynchronous add.
+type EventStatus string
+
+const (
+	EventStatusPending   EventStatus = "PENDING"
+	EventStatusRunning   EventStatus = "RUNNING"
+	EventStatusSucceeded EventStatus = "SUCCEEDED"
+	EventStatusFailed    EventStatus = "FAILED"
+)
+
+// Terminal reports whether the event has finished processing.
+func (s EventStatus) Terminal() bool {
+	return s == EventStatusSucceeded || s == EventStatusFailed
+}
+
+// Event is a background job created by an asynchronous AddMemories call.
+type Event struct {
+	ID        string         `json:"id"`
+	EventType string         `json:"event_type,omitempty"`
+	Status    EventStatus    `json:"status"`
+	Payload   map[string]any `json:"payload,omitempty"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+	Results   []EventResult  `json:"results,omitempty"`
+	Latency   float64        `json:"latency,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// EventResult is a memory operation produced by an event.
+type EventResult struct {
+	ID     string `json:"id"`
+	Event  string `json:"event"`
+	UserID string `json:"user_id,omitempty"`
+	Data   struct {
+		Memory string `json:"memory"`
+	} `json:"data"`
+}
+
+// GetEvent retrieves the status and results of an asynchronous add.
+func (c *Client) GetEvent(ctx context.Context, eventID string) (*Event, error) {
+	if eventID == "" {
+		return nil, ErrMissingID
+	}
+
+	var event Event
+	if err := c.do(ctx, "GetEvent", http.MethodGet, "/v1/event/"+url.PathEscape(eventID)+"/", nil, nil, &event); err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// WaitOptions controls how WaitForEvents polls.
+type WaitOptions struct {
+	// PollInterval is the delay before the first poll. It doubles after each
+	// round up to MaxInterval. Defaults to 500ms.
+	PollInterval time.Duration
+	// MaxInterval caps the delay between polls. Defaults to 5s.
+	MaxInterval time.Duration
+}
+
+// WaitForEvents polls every event in resp until all reach a terminal state
+// and returns the memory operations they produced, in the same shape as a
+// synchronous add. Results that carry no event ID are returned as is, and
+// each event is polled once however many results refer to it.
+//
+// Use ctx to bound the wait. If an event fails, the operations collected so
+// far are returned with an error wrapping ErrEventFailed.
+func (c *Client) WaitForEvents(ctx context.Context, resp *AddMemoriesResponse, opts *WaitOptions) ([]AddEvent, error) {
+	if resp == nil {
+		return nil, ErrEmptyRequest
+	}
+
+	interval, maxInterval := 500*time.Millisecond, 5*time.Second
+	if opts != nil {
+		if opts.PollInterval > 0 {
+			interval = opts.PollInterval
+		}
+		if opts.MaxInterval > 0 {
+			maxInterval = opts.MaxInterval
+		}
+	}
+
+	var results []AddEvent
+	var pending []string
+	for _, r := range resp.Results {
+		if r.EventID == "" {
+			results = append(results, r)
+			continue
+		}
+		// Several results may refer to one event; poll it once.
+		if !slices.Contains(pending, r.EventID) {
+			pending = append(pending, r.EventID)
+		}
+	}
+
+	for len(pending) > 0 {
+		if !sleepCtx(ctx, interval) {
+			if err := ctx.Err(); err != nil {
+				return results, err
+			}
+			return results, context.DeadlineExceeded
+		}
+		interval = min(interval*2, maxInterval)
+
+		var still []string
+		for _, id := range pending {
+			event, err := c.GetEvent(ctx, id)
+			if err != nil {
+				return results, err
+			}
+
+			switch event.Status {
+			case EventStatusSucceeded:
+				for _, r := range event.Results {
+					results = append(results, AddEvent{
+						ID:      r.ID,
+						EventID: event.ID,
+						Event:   r.Event,
+						Status:  string(event.Status),
+						Memory:  r.Data.Memory,
+					})
+				}
+			case EventStatusFailed:
+				return results, fmt.Errorf("%w: %s", ErrEventFailed, event.ID)
+			default:
+				still = append(still, id)
+			}
+		}
+		pending = still
+	}
+
+	return results, nil
+}
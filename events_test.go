@@ -0,0 +1,100 @@
+package mem0
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWaitForEvents(t *testing.T) {
+	var mu sync.Mutex
+	polls := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+
+		mu.Lock()
+		id := r.URL.Path[len("/v1/event/") : len(r.URL.Path)-1]
+		polls[id]++
+		n := polls[id]
+		mu.Unlock()
+
+		event := Event{ID: id, Status: EventStatusRunning}
+		if n >= 2 {
+			event.Status = EventStatusSucceeded
+			event.Results = []EventResult{{ID: "mem-" + id, Event: "ADD"}}
+			event.Results[0].Data.Memory = "memory from " + id
+		}
+		json.NewEncoder(w).Encode(event)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL))
+
+	resp := &AddMemoriesResponse{Results: []AddEvent{
+		{EventID: "evt-1", Status: "PENDING"},
+		{EventID: "evt-2", Status: "PENDING"},
+		{EventID: "evt-1", Status: "PENDING"},
+	}}
+	ops, err := client.WaitForEvents(context.Background(), resp, &WaitOptions{PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ops) != 2 {
+		t.Fatalf("expected 2 operations, got %d", len(ops))
+	}
+	if polls["evt-1"] != 2 {
+		t.Errorf("expected repeated event to be polled once per round, got %d polls", polls["evt-1"])
+	}
+	if ops[0].ID != "mem-evt-1" || ops[0].Event != "ADD" || ops[0].Memory != "memory from evt-1" {
+		t.Errorf("unexpected operation: %+v", ops[0])
+	}
+}
+
+func TestWaitForEventsFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Event{ID: "evt-1", Status: EventStatusFailed})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL))
+
+	resp := &AddMemoriesResponse{Results: []AddEvent{{EventID: "evt-1"}}}
+	_, err := client.WaitForEvents(context.Background(), resp, &WaitOptions{PollInterval: time.Millisecond})
+	if !errors.Is(err, ErrEventFailed) {
+		t.Errorf("expected ErrEventFailed, got %v", err)
+	}
+}
+
+func TestWaitForEventsTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Event{ID: "evt-1", Status: EventStatusPending})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	resp := &AddMemoriesResponse{Results: []AddEvent{{EventID: "evt-1"}}}
+	_, err := client.WaitForEvents(ctx, resp, &WaitOptions{PollInterval: 5 * time.Millisecond})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestGetEventValidation(t *testing.T) {
+	client, _ := NewClient("test-key")
+
+	_, err := client.GetEvent(context.Background(), "")
+	if err != ErrMissingID {
+		t.Errorf("expected ErrMissingID, got %v", err)
+	}
+}
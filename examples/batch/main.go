@@ -68,7 +68,7 @@ func main() {
 
 	// Batch update
 	fmt.Println("\n=== Batch Update ===")
-	updateResp, err := client.BatchUpdate(ctx, &mem0.BatchUpdateRequest{
+	updateResult, err := client.BatchUpdate(ctx, &mem0.BatchUpdateRequest{
 		Memories: []mem0.BatchUpdateItem{
 			{MemoryID: memoryIDs[0], Text: "Updated: I LOVE pizza"},
 			{MemoryID: memoryIDs[1], Text: "Updated: I work from home 3 days a week"},
@@ -77,11 +77,11 @@ func main() {
 	if err != nil {
 		log.Fatalf("Batch update failed: %v", err)
 	}
-	fmt.Printf("Result: %s\n", updateResp.Message)
+	fmt.Printf("Updated %d memories\n", len(updateResult.Succeeded))
 
 	// Batch delete
 	fmt.Println("\n=== Batch Delete ===")
-	err = client.BatchDelete(ctx, &mem0.BatchDeleteRequest{
+	_, err = client.BatchDelete(ctx, &mem0.BatchDeleteRequest{
 		MemoryIDs: memoryIDs,
 	})
 	if err != nil {
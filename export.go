@@ -0,0 +1,274 @@
+package mem0
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// Export writes every memory matching filters to w as JSON lines, one
+// Memory per line with its metadata, categories, immutability and
+// expiration. It returns the number of memories written.
+func (c *Client) Export(ctx context.Context, filters Filters, w io.Writer, opts ...PageOption) (int, error) {
+	if filters == nil {
+		return 0, ErrMissingFilters
+	}
+	enc := json.NewEncoder(w)
+	n := 0
+	for m, err := range c.AllMemories(ctx, filters, opts...) {
+		if err != nil {
+			return n, err
+		}
+		if err := enc.Encode(m); err != nil {
+			return n, fmt.Errorf("mem0: export: %w", err)
+		}
+		n++
+	}
+	return n, nil
+}
+
+// ImportOptions controls Import.
+type ImportOptions struct {
+	// DryRun parses and validates every line without adding anything.
+	DryRun bool
+	// Checkpoint is the path of a file recording each imported memory's
+	// exported ID and new ID. Re-running an interrupted import with the
+	// same checkpoint skips memories already imported, matching them by
+	// exported ID, or by line number for memories without one. Each entry
+	// is fsynced as it is written. Empty disables checkpointing.
+	Checkpoint string
+}
+
+// ImportLineError reports a line of the input that could not be imported.
+type ImportLineError struct {
+	Line int // 1-based
+	ID   string
+	Err  error
+}
+
+func (e *ImportLineError) Error() string {
+	if e.ID != "" {
+		return fmt.Sprintf("mem0: import line %d (memory %s): %v", e.Line, e.ID, e.Err)
+	}
+	return fmt.Sprintf("mem0: import line %d: %v", e.Line, e.Err)
+}
+
+func (e *ImportLineError) Unwrap() error { return e.Err }
+
+// ImportResult summarizes an Import.
+type ImportResult struct {
+	// Imported is the number of memories added, or that would be added in
+	// a dry run.
+	Imported int
+	// Skipped is the number of memories already recorded in the
+	// checkpoint.
+	Skipped int
+	// IDMap maps exported memory IDs to the IDs of the memories created
+	// for them, including those restored from the checkpoint.
+	IDMap  map[string]string
+	Failed []*ImportLineError
+}
+
+// Err returns nil if no line failed, and otherwise an error wrapping every
+// line error.
+func (r *ImportResult) Err() error {
+	if r == nil || len(r.Failed) == 0 {
+		return nil
+	}
+	errs := make([]error, len(r.Failed))
+	for i, e := range r.Failed {
+		errs[i] = e
+	}
+	return errors.Join(errs...)
+}
+
+type importCheckpoint struct {
+	Line  int    `json:"line"`
+	OldID string `json:"old_id,omitempty"`
+	NewID string `json:"new_id,omitempty"`
+}
+
+// Import re-creates the memories in r, as written by Export, with one
+// AddMemories call per memory. Memories are added verbatim (Infer false)
+// in their original user, agent, app and run scope, with their metadata,
+// immutability, expiration and creation time. Categories are reassigned by
+// the platform.
+//
+// A line that fails is reported in the result and the import continues; if
+// any line failed the returned error is non-nil. An error reading r or the
+// checkpoint stops the import.
+func (c *Client) Import(ctx context.Context, r io.Reader, opts *ImportOptions) (*ImportResult, error) {
+	if opts == nil {
+		opts = &ImportOptions{}
+	}
+	res := &ImportResult{IDMap: make(map[string]string)}
+
+	done := &importDone{ids: make(map[string]bool), lines: make(map[int]bool)}
+	var checkpoint *os.File
+	if opts.Checkpoint != "" {
+		var err error
+		if done, err = readImportCheckpoint(opts.Checkpoint, res.IDMap); err != nil {
+			return nil, err
+		}
+		if !opts.DryRun {
+			checkpoint, err = os.OpenFile(opts.Checkpoint, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+			if err != nil {
+				return nil, fmt.Errorf("mem0: open checkpoint: %w", err)
+			}
+			defer checkpoint.Close()
+		}
+	}
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 16<<20)
+	for line := 1; sc.Scan(); line++ {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+		text := bytes.TrimSpace(sc.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		var m Memory
+		if err := json.Unmarshal(text, &m); err != nil {
+			res.Failed = append(res.Failed, &ImportLineError{Line: line, Err: err})
+			continue
+		}
+		if done.has(m.ID, line) {
+			res.Skipped++
+			continue
+		}
+		if opts.DryRun {
+			if _, err := ImportRequest(m); err != nil {
+				res.Failed = append(res.Failed, &ImportLineError{Line: line, ID: m.ID, Err: err})
+			} else {
+				res.Imported++
+			}
+			continue
+		}
+
+		newID, err := c.ImportMemory(ctx, m)
+		if err != nil {
+			res.Failed = append(res.Failed, &ImportLineError{Line: line, ID: m.ID, Err: err})
+			continue
+		}
+		res.Imported++
+		if m.ID != "" {
+			res.IDMap[m.ID] = newID
+		}
+		if checkpoint != nil {
+			data, _ := json.Marshal(importCheckpoint{Line: line, OldID: m.ID, NewID: newID})
+			if _, err := checkpoint.Write(append(data, '\n')); err != nil {
+				return res, fmt.Errorf("mem0: write checkpoint: %w", err)
+			}
+			if err := checkpoint.Sync(); err != nil {
+				return res, fmt.Errorf("mem0: write checkpoint: %w", err)
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return res, fmt.Errorf("mem0: read import: %w", err)
+	}
+	return res, res.Err()
+}
+
+// ImportMemory re-creates an exported memory with one AddMemories call, as
+// Import does for each line, and returns the new memory's ID.
+func (c *Client) ImportMemory(ctx context.Context, m Memory) (string, error) {
+	req, err := ImportRequest(m)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.AddMemories(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Results) == 0 || resp.Results[0].ID == "" {
+		return "", errors.New("no memory was created")
+	}
+	return resp.Results[0].ID, nil
+}
+
+// ImportRequest returns the request that re-creates m verbatim: Infer and
+// AsyncMode are off, and the scope, metadata, immutability, expiration and
+// creation time are copied. It fails if m has no text or no user, agent,
+// app or run ID.
+func ImportRequest(m Memory) (*AddMemoriesRequest, error) {
+	text := strings.TrimSpace(m.Memory)
+	if text == "" {
+		return nil, errors.New("memory text is empty")
+	}
+	if m.UserID == "" && m.AgentID == "" && m.AppID == "" && m.RunID == "" {
+		return nil, errors.New("memory has no user, agent, app or run ID")
+	}
+	infer, async := false, false
+	req := &AddMemoriesRequest{
+		Messages:       []Message{{Role: "user", Content: text}},
+		UserID:         m.UserID,
+		AgentID:        m.AgentID,
+		AppID:          m.AppID,
+		RunID:          m.RunID,
+		Metadata:       m.Metadata,
+		Infer:          &infer,
+		AsyncMode:      &async,
+		Immutable:      m.Immutable,
+		ExpirationDate: m.ExpirationDate,
+	}
+	if !m.CreatedAt.IsZero() {
+		req.Timestamp = m.CreatedAt.Unix()
+	}
+	return req, nil
+}
+
+// importDone holds the memories recorded in an import checkpoint. Memories
+// with an exported ID are keyed by it, so a resumed import skips the right
+// memories even if lines were added or removed since; the rest are keyed
+// by line number.
+type importDone struct {
+	ids   map[string]bool
+	lines map[int]bool
+}
+
+func (d *importDone) has(id string, line int) bool {
+	if id != "" {
+		return d.ids[id]
+	}
+	return d.lines[line]
+}
+
+// readImportCheckpoint returns the memories recorded in the checkpoint at
+// path and adds their ID mappings to ids. A missing file is an empty
+// checkpoint.
+func readImportCheckpoint(path string, ids map[string]string) (*importDone, error) {
+	done := &importDone{ids: make(map[string]bool), lines: make(map[int]bool)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return done, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("mem0: read checkpoint: %w", err)
+	}
+
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		var cp importCheckpoint
+		if len(bytes.TrimSpace(line)) == 0 || json.Unmarshal(line, &cp) != nil {
+			// Skip a line torn by a crash; that memory is imported again.
+			continue
+		}
+		if cp.OldID == "" {
+			done.lines[cp.Line] = true
+			continue
+		}
+		done.ids[cp.OldID] = true
+		ids[cp.OldID] = cp.NewID
+	}
+	return done, nil
+}
@@ -0,0 +1,155 @@
+package mem0
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExport(t *testing.T) {
+	created := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var results []Memory
+		switch r.URL.Query().Get("page") {
+		case "1":
+			results = []Memory{
+				{ID: "a", Memory: "Likes ramen", UserID: "u1", Categories: []string{"food"}, CreatedAt: created},
+				{ID: "b", Memory: "Allergic to peanuts", UserID: "u1", Immutable: true, Metadata: map[string]any{"source": "intake"}},
+			}
+		case "2":
+			results = []Memory{{ID: "c", Memory: "Lives in Lisbon", UserID: "u1", ExpirationDate: "2030-01-01"}}
+		}
+		json.NewEncoder(w).Encode(GetMemoriesResponse{Results: results, Total: 3})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL))
+	var buf bytes.Buffer
+	n, err := client.Export(context.Background(), NewFilters().WithUserID("u1"), &buf, WithPageSize(2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if n != 3 || len(lines) != 3 {
+		t.Fatalf("expected 3 memories on 3 lines, got %d and %q", n, lines)
+	}
+
+	var b Memory
+	json.Unmarshal([]byte(lines[1]), &b)
+	if b.ID != "b" || !b.Immutable || b.Metadata["source"] != "intake" {
+		t.Errorf("expected b with immutability and metadata, got %+v", b)
+	}
+}
+
+// importServer assigns sequential IDs to added memories and fails adds whose
+// text contains "fail".
+func importServer(t *testing.T) (*Client, *[]AddMemoriesRequest) {
+	t.Helper()
+	var reqs []AddMemoriesRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req AddMemoriesRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if strings.Contains(req.Messages[0].Content, "fail") {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"detail":"rejected"}`))
+			return
+		}
+		reqs = append(reqs, req)
+		json.NewEncoder(w).Encode(AddMemoriesResponse{Results: []AddEvent{
+			{ID: fmt.Sprintf("new-%d", len(reqs)), Event: "ADD", Memory: req.Messages[0].Content},
+		}})
+	}))
+	t.Cleanup(server.Close)
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL))
+	return client, &reqs
+}
+
+const importInput = `{"id":"a","memory":"Likes ramen","user_id":"u1","immutable":true,"created_at":"2024-05-01T00:00:00Z"}
+not json
+
+{"id":"b","memory":"Allergic to peanuts","agent_id":"bot","metadata":{"source":"intake"}}
+{"id":"c","memory":"please fail","user_id":"u1"}
+{"id":"d","memory":"No scope"}
+`
+
+func TestImport(t *testing.T) {
+	client, reqs := importServer(t)
+
+	res, err := client.Import(context.Background(), strings.NewReader(importInput), nil)
+	if err == nil {
+		t.Error("expected error for failed lines")
+	}
+	if res.Imported != 2 || res.IDMap["a"] != "new-1" || res.IDMap["b"] != "new-2" {
+		t.Errorf("expected a and b imported, got %+v", res)
+	}
+	if len(res.Failed) != 3 || res.Failed[0].Line != 2 || res.Failed[1].ID != "c" || res.Failed[2].Line != 6 {
+		t.Errorf("expected failures on lines 2, 5 and 6, got %v", res.Err())
+	}
+
+	a := (*reqs)[0]
+	if a.Infer == nil || *a.Infer || !a.Immutable || a.UserID != "u1" || a.Timestamp != 1714521600 {
+		t.Errorf("expected verbatim immutable add for u1 with original timestamp, got %+v", a)
+	}
+	if b := (*reqs)[1]; b.AgentID != "bot" || b.Metadata["source"] != "intake" {
+		t.Errorf("expected b in agent scope with metadata, got %+v", b)
+	}
+}
+
+func TestImportDryRunAndCheckpoint(t *testing.T) {
+	client, reqs := importServer(t)
+	ctx := context.Background()
+	checkpoint := filepath.Join(t.TempDir(), "import.ckpt")
+
+	res, _ := client.Import(ctx, strings.NewReader(importInput), &ImportOptions{DryRun: true, Checkpoint: checkpoint})
+	if len(*reqs) != 0 || res.Imported != 3 || len(res.Failed) != 2 {
+		t.Errorf("expected dry run to validate 3 lines and add nothing, got %d adds and %+v", len(*reqs), res)
+	}
+
+	client.Import(ctx, strings.NewReader(importInput), &ImportOptions{Checkpoint: checkpoint})
+	res, _ = client.Import(ctx, strings.NewReader(importInput), &ImportOptions{Checkpoint: checkpoint})
+	if len(*reqs) != 2 {
+		t.Errorf("expected resumed import to skip imported lines, got %d adds", len(*reqs))
+	}
+	if res.Skipped != 2 || res.Imported != 0 || res.IDMap["a"] != "new-1" {
+		t.Errorf("expected 2 skipped with restored ID map, got %+v", res)
+	}
+}
+
+func TestImportCheckpointMatchesByID(t *testing.T) {
+	client, reqs := importServer(t)
+	ctx := context.Background()
+	checkpoint := filepath.Join(t.TempDir(), "import.ckpt")
+
+	first := `{"id":"a","memory":"Likes ramen","user_id":"u1"}
+{"id":"b","memory":"Lives in Lisbon","user_id":"u1"}
+`
+	client.Import(ctx, strings.NewReader(first), &ImportOptions{Checkpoint: checkpoint})
+
+	// The file was edited: a new memory now sits on line 1 and b was
+	// removed, so line numbers no longer match the checkpoint.
+	edited := `{"id":"z","memory":"Drives an EV","user_id":"u1"}
+{"id":"a","memory":"Likes ramen","user_id":"u1"}
+{"id":"c","memory":"Owns a cat","user_id":"u1"}
+`
+	res, err := client.Import(ctx, strings.NewReader(edited), &ImportOptions{Checkpoint: checkpoint})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Skipped != 1 || res.Imported != 2 {
+		t.Errorf("expected a skipped and z and c imported, got %+v", res)
+	}
+	if res.IDMap["a"] != "new-1" || res.IDMap["z"] != "new-3" || res.IDMap["c"] != "new-4" {
+		t.Errorf("unexpected ID map %v", res.IDMap)
+	}
+	if len(*reqs) != 4 {
+		t.Errorf("expected 4 adds in total, got %d", len(*reqs))
+	}
+}
@@ -0,0 +1,395 @@
+package mem0
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// ErrInvalidFilter is wrapped by errors returned from FilterExpr.Validate
+// and Filters.Validate.
+var ErrInvalidFilter = errors.New("mem0: invalid filter")
+
+// Filter operators of the platform's v2 filter grammar.
+const (
+	OpEq        = "eq"
+	OpNe        = "ne"
+	OpGt        = "gt"
+	OpGte       = "gte"
+	OpLt        = "lt"
+	OpLte       = "lte"
+	OpIn        = "in"
+	OpNin       = "nin"
+	OpContains  = "contains"
+	OpIContains = "icontains"
+)
+
+// Wildcard matches any non-empty value of a field.
+const Wildcard = "*"
+
+// FilterExpr is a node in a typed filter tree. Build one with the
+// condition constructors (Eq, In, Gte, ...), combine nodes with And, Or and
+// Not, and pass it to a request with Filters:
+//
+//	expr := mem0.And(
+//	    mem0.Eq("user_id", "u1"),
+//	    mem0.Not(mem0.In("categories", "health")),
+//	    mem0.Metadata("tier").Eq("gold"),
+//	)
+//	if err := expr.Validate(); err != nil {
+//	    return err
+//	}
+//	req := &mem0.SearchRequest{Query: q, Filters: expr.Filters()}
+//
+// A FilterExpr marshals to JSON in the platform's v2 filter grammar.
+type FilterExpr interface {
+	json.Marshaler
+
+	// Validate reports the first structural problem in the tree, such as an
+	// empty group, a missing field or an operand of the wrong type.
+	Validate() error
+
+	// Filters converts the expression to the map form used by requests.
+	Filters() Filters
+
+	filterExpr()
+}
+
+// Condition compares a single field with a value.
+type Condition struct {
+	Field string
+	Op    string
+	Value any
+
+	// MetadataKey, if set, makes the condition apply to a key of the
+	// memory's metadata instead of a top-level field.
+	MetadataKey string
+}
+
+// Group combines expressions with a logical operator: "AND", "OR" or "NOT".
+// A NOT group matches when none of its expressions match.
+type Group struct {
+	Op    string
+	Exprs []FilterExpr
+}
+
+func (*Condition) filterExpr() {}
+func (*Group) filterExpr()     {}
+
+func cond(field, op string, value any) *Condition {
+	return &Condition{Field: field, Op: op, Value: value}
+}
+
+// Eq matches memories whose field equals value.
+func Eq(field string, value any) *Condition { return cond(field, OpEq, value) }
+
+// Ne matches memories whose field does not equal value.
+func Ne(field string, value any) *Condition { return cond(field, OpNe, value) }
+
+// Gt matches memories whose field is greater than value. Times compare
+// chronologically.
+func Gt(field string, value any) *Condition { return cond(field, OpGt, value) }
+
+// Gte matches memories whose field is greater than or equal to value.
+func Gte(field string, value any) *Condition { return cond(field, OpGte, value) }
+
+// Lt matches memories whose field is less than value.
+func Lt(field string, value any) *Condition { return cond(field, OpLt, value) }
+
+// Lte matches memories whose field is less than or equal to value.
+func Lte(field string, value any) *Condition { return cond(field, OpLte, value) }
+
+// In matches memories whose field equals one of values, or for list fields
+// such as categories, contains one of them.
+func In[T any](field string, values ...T) *Condition { return cond(field, OpIn, values) }
+
+// Nin matches memories whose field equals none of values.
+func Nin[T any](field string, values ...T) *Condition { return cond(field, OpNin, values) }
+
+// Contains matches memories whose field contains substr, or for list
+// fields, the element substr.
+func Contains(field, substr string) *Condition { return cond(field, OpContains, substr) }
+
+// IContains is the case-insensitive form of Contains.
+func IContains(field, substr string) *Condition { return cond(field, OpIContains, substr) }
+
+// Any matches memories with any non-empty value for field.
+func Any(field string) *Condition { return cond(field, OpEq, Wildcard) }
+
+// And matches memories satisfying every expression.
+func And(exprs ...FilterExpr) *Group { return &Group{Op: "AND", Exprs: exprs} }
+
+// Or matches memories satisfying at least one expression.
+func Or(exprs ...FilterExpr) *Group { return &Group{Op: "OR", Exprs: exprs} }
+
+// Not matches memories satisfying none of the expressions.
+func Not(exprs ...FilterExpr) *Group { return &Group{Op: "NOT", Exprs: exprs} }
+
+// MetadataField builds conditions on a key of the memory's metadata.
+type MetadataField string
+
+// Metadata returns a builder for conditions on the metadata key.
+func Metadata(key string) MetadataField { return MetadataField(key) }
+
+func (k MetadataField) cond(op string, value any) *Condition {
+	return &Condition{Field: "metadata", Op: op, Value: value, MetadataKey: string(k)}
+}
+
+func (k MetadataField) Eq(value any) *Condition  { return k.cond(OpEq, value) }
+func (k MetadataField) Ne(value any) *Condition  { return k.cond(OpNe, value) }
+func (k MetadataField) Gt(value any) *Condition  { return k.cond(OpGt, value) }
+func (k MetadataField) Gte(value any) *Condition { return k.cond(OpGte, value) }
+func (k MetadataField) Lt(value any) *Condition  { return k.cond(OpLt, value) }
+func (k MetadataField) Lte(value any) *Condition { return k.cond(OpLte, value) }
+
+func (k MetadataField) In(values ...any) *Condition  { return k.cond(OpIn, values) }
+func (k MetadataField) Nin(values ...any) *Condition { return k.cond(OpNin, values) }
+
+func (k MetadataField) Contains(substr string) *Condition  { return k.cond(OpContains, substr) }
+func (k MetadataField) IContains(substr string) *Condition { return k.cond(OpIContains, substr) }
+
+func invalidf(format string, args ...any) error {
+	return fmt.Errorf("%w: "+format, append([]any{ErrInvalidFilter}, args...)...)
+}
+
+func (c *Condition) Validate() error {
+	if c == nil {
+		return invalidf("nil condition")
+	}
+	if c.Field == "" {
+		return invalidf("condition has no field")
+	}
+	if c.Field == "metadata" && c.MetadataKey == "" {
+		return invalidf("metadata conditions need a key; use Metadata(key)")
+	}
+	if c.MetadataKey != "" && c.Field != "metadata" {
+		return invalidf("%s: metadata key set on a non-metadata field", c.Field)
+	}
+
+	name := c.Field
+	if c.MetadataKey != "" {
+		name = "metadata." + c.MetadataKey
+	}
+
+	switch c.Op {
+	case OpEq, OpNe:
+		if c.Value == nil {
+			return invalidf("%s %s: value is required", name, c.Op)
+		}
+	case OpGt, OpGte, OpLt, OpLte:
+		if !isOrdered(c.Value) {
+			return invalidf("%s %s: expected a number, time or date string, got %T", name, c.Op, c.Value)
+		}
+	case OpIn, OpNin:
+		v := reflect.ValueOf(c.Value)
+		if !v.IsValid() || v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return invalidf("%s %s: expected a list, got %T", name, c.Op, c.Value)
+		}
+		if v.Len() == 0 {
+			return invalidf("%s %s: list is empty", name, c.Op)
+		}
+	case OpContains, OpIContains:
+		if s, ok := c.Value.(string); !ok || s == "" {
+			return invalidf("%s %s: expected a non-empty string, got %T", name, c.Op, c.Value)
+		}
+	default:
+		return invalidf("%s: unknown operator %q", name, c.Op)
+	}
+	return nil
+}
+
+func isOrdered(v any) bool {
+	switch v.(type) {
+	case time.Time, string:
+		return true
+	}
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// operand returns the condition's value or operator map, with times
+// formatted as RFC3339.
+func (c *Condition) operand() any {
+	v := c.Value
+	if t, ok := v.(time.Time); ok {
+		v = t.Format(time.RFC3339)
+	}
+	if c.Op == OpEq {
+		return v
+	}
+	return map[string]any{c.Op: v}
+}
+
+func (c *Condition) Filters() Filters {
+	if c.MetadataKey != "" {
+		return Filters{"metadata": map[string]any{c.MetadataKey: c.operand()}}
+	}
+	return Filters{c.Field: c.operand()}
+}
+
+func (c *Condition) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Filters())
+}
+
+func (g *Group) Validate() error {
+	if g == nil {
+		return invalidf("nil group")
+	}
+	if !slices.Contains([]string{"AND", "OR", "NOT"}, g.Op) {
+		return invalidf("unknown logical operator %q", g.Op)
+	}
+	if len(g.Exprs) == 0 {
+		return invalidf("%s group is empty", g.Op)
+	}
+	for _, e := range g.Exprs {
+		if e == nil || reflect.ValueOf(e).IsNil() {
+			return invalidf("%s group contains a nil expression", g.Op)
+		}
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *Group) Filters() Filters {
+	parts := make([]Filters, len(g.Exprs))
+	for i, e := range g.Exprs {
+		parts[i] = e.Filters()
+	}
+	return Filters{g.Op: parts}
+}
+
+func (g *Group) MarshalJSON() ([]byte, error) {
+	return json.Marshal(g.Filters())
+}
+
+// Expr converts the filters to a typed expression. Several keys at one level
+// become an AND group; a field with several operators becomes one condition
+// per operator.
+func (f Filters) Expr() (FilterExpr, error) {
+	// Round-trip through JSON so nested Filters, maps and slices of any
+	// concrete type are seen uniformly.
+	data, err := json.Marshal(f)
+	if err != nil {
+		return nil, invalidf("%v", err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, invalidf("%v", err)
+	}
+	return parseFilterMap(raw)
+}
+
+// Validate reports whether the filters form a valid expression.
+func (f Filters) Validate() error {
+	expr, err := f.Expr()
+	if err != nil {
+		return err
+	}
+	return expr.Validate()
+}
+
+// validateFilters validates f unless it is empty. Empty filters are sent
+// as-is and left to the API, as they were before client-side validation.
+func validateFilters(f Filters) error {
+	if len(f) == 0 {
+		return nil
+	}
+	return f.Validate()
+}
+
+func parseFilterMap(m map[string]any) (FilterExpr, error) {
+	if len(m) == 0 {
+		return nil, invalidf("empty filter")
+	}
+
+	var exprs []FilterExpr
+	for _, key := range sortedKeys(m) {
+		parsed, err := parseFilterKey(key, m[key])
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, parsed...)
+	}
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return And(exprs...), nil
+}
+
+func parseFilterKey(key string, value any) ([]FilterExpr, error) {
+	switch key {
+	case "AND", "OR", "NOT":
+		list, ok := value.([]any)
+		if !ok {
+			return nil, invalidf("%s expects a list of filters, got %T", key, value)
+		}
+		g := &Group{Op: key}
+		for _, item := range list {
+			sub, ok := item.(map[string]any)
+			if !ok {
+				return nil, invalidf("%s expects a list of filters, got %T element", key, item)
+			}
+			e, err := parseFilterMap(sub)
+			if err != nil {
+				return nil, err
+			}
+			g.Exprs = append(g.Exprs, e)
+		}
+		return []FilterExpr{g}, nil
+
+	case "metadata":
+		fields, ok := value.(map[string]any)
+		if !ok {
+			return nil, invalidf("metadata expects an object, got %T", value)
+		}
+		var out []FilterExpr
+		for _, k := range sortedKeys(fields) {
+			for _, c := range parseOperand(key, fields[k]) {
+				c.MetadataKey = k
+				out = append(out, c)
+			}
+		}
+		return out, nil
+	}
+
+	var out []FilterExpr
+	for _, c := range parseOperand(key, value) {
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// parseOperand turns a bare value or an operator map into conditions.
+func parseOperand(field string, value any) []*Condition {
+	ops, ok := value.(map[string]any)
+	if !ok {
+		return []*Condition{cond(field, OpEq, value)}
+	}
+	if len(ops) == 0 {
+		return []*Condition{cond(field, "", nil)} // rejected by Validate
+	}
+	var out []*Condition
+	for _, op := range sortedKeys(ops) {
+		out = append(out, cond(field, op, ops[op]))
+	}
+	return out
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
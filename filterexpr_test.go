@@ -0,0 +1,155 @@
+package mem0
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFilterExprJSON(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		expr FilterExpr
+		want string
+	}{
+		{Eq("user_id", "u1"), `{"user_id":"u1"}`},
+		{Ne("agent_id", "a1"), `{"agent_id":{"ne":"a1"}}`},
+		{Any("run_id"), `{"run_id":"*"}`},
+		{In("categories", "travel", "food"), `{"categories":{"in":["travel","food"]}}`},
+		{Gte("created_at", since), `{"created_at":{"gte":"2026-01-01T00:00:00Z"}}`},
+		{IContains("memory", "ramen"), `{"memory":{"icontains":"ramen"}}`},
+		{Metadata("tier").Eq("gold"), `{"metadata":{"tier":"gold"}}`},
+		{Metadata("score").Gt(5), `{"metadata":{"score":{"gt":5}}}`},
+		{
+			And(Eq("user_id", "u1"), Or(In("categories", "travel"), Not(Eq("app_id", "x")))),
+			`{"AND":[{"user_id":"u1"},{"OR":[{"categories":{"in":["travel"]}},{"NOT":[{"app_id":"x"}]}]}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.expr)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.want, err)
+			continue
+		}
+		if string(data) != tt.want {
+			t.Errorf("expected %s, got %s", tt.want, data)
+		}
+		if err := tt.expr.Validate(); err != nil {
+			t.Errorf("%s: unexpected validation error: %v", tt.want, err)
+		}
+	}
+}
+
+func TestFilterExprValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		expr FilterExpr
+	}{
+		{"empty field", Eq("", "x")},
+		{"nil value", Eq("user_id", nil)},
+		{"unknown operator", &Condition{Field: "user_id", Op: "between", Value: 1}},
+		{"empty in", In[string]("categories")},
+		{"ordered bool", Gt("created_at", true)},
+		{"contains non-string", &Condition{Field: "memory", Op: OpContains, Value: 3}},
+		{"bare metadata", Eq("metadata", map[string]any{"tier": "gold"})},
+		{"empty group", And()},
+		{"nested error", Or(Eq("user_id", "u1"), Not(In[string]("categories")))},
+		{"nil child", And(Eq("user_id", "u1"), (*Condition)(nil))},
+	}
+
+	for _, tt := range tests {
+		err := tt.expr.Validate()
+		if !errors.Is(err, ErrInvalidFilter) {
+			t.Errorf("%s: expected ErrInvalidFilter, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestFiltersExpr(t *testing.T) {
+	f := Filters{
+		"user_id":  "u1",
+		"metadata": map[string]any{"score": map[string]any{"gte": 5, "lte": 9}},
+		"OR":       []Filters{{"agent_id": "a1"}, {"app_id": "*"}},
+	}
+	expr, err := f.Expr()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := expr.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	g, ok := expr.(*Group)
+	if !ok || g.Op != "AND" || len(g.Exprs) != 4 {
+		t.Fatalf("expected AND of 4 expressions, got %#v", expr)
+	}
+
+	bad := Filters{"user_id": map[string]any{"between": []int{1, 2}}}
+	if err := bad.Validate(); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("expected ErrInvalidFilter for unknown operator, got %v", err)
+	}
+	if err := (Filters{"AND": "u1"}).Validate(); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("expected ErrInvalidFilter for non-list AND, got %v", err)
+	}
+}
+
+func TestFiltersChainingAfterOr(t *testing.T) {
+	f := NewFilters().WithUserID("u1").
+		Or(NewFilters().WithUserID("u2")).
+		WithAgentID("a1").
+		WithCreatedAfter(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	data, _ := json.Marshal(f)
+	want := `{"AND":[{"OR":[{"user_id":"u1"},{"user_id":"u2"}]},{"agent_id":"a1"},{"created_at":{"gte":"2026-01-01T00:00:00Z"}}]}`
+	if string(data) != want {
+		t.Errorf("expected %s, got %s", want, data)
+	}
+}
+
+func TestSearchRejectsInvalidFilters(t *testing.T) {
+	client, _ := NewClient("test-key", WithBaseURL("http://127.0.0.1:0"))
+
+	_, err := client.Search(context.Background(), &SearchRequest{
+		Query:   "test",
+		Filters: Filters{"user_id": map[string]any{"in": "u1"}},
+	})
+	if !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("expected ErrInvalidFilter, got %v", err)
+	}
+}
+
+func TestSearchAcceptsEmptyFilters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]Memory{})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL))
+	if _, err := client.Search(context.Background(), &SearchRequest{Query: "test", Filters: NewFilters()}); err != nil {
+		t.Errorf("expected empty filters to be sent, got %v", err)
+	}
+}
+
+func TestFiltersAndWithCopiesGroup(t *testing.T) {
+	base := NewFilters().WithUserID("u1").
+		Or(NewFilters().WithUserID("u2")).
+		WithAgentID("a1").
+		WithAppID("app")
+
+	a := maps.Clone(base).WithRunID("r1")
+	b := maps.Clone(base).WithRunID("r2")
+
+	want := `{"AND":[{"OR":[{"user_id":"u1"},{"user_id":"u2"}]},{"agent_id":"a1"},{"app_id":"app"},{"run_id":"r1"}]}`
+	if data, _ := json.Marshal(a); string(data) != want {
+		t.Errorf("expected %s, got %s", want, data)
+	}
+	if len(b["AND"].([]Filters)) != 4 || len(base["AND"].([]Filters)) != 3 {
+		t.Errorf("expected clones to extend their own groups, got %v and %v", b, base)
+	}
+}
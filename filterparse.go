@@ -0,0 +1,486 @@
+package mem0
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SyntaxError reports a malformed filter string. Pos is the 1-based byte
+// offset of the offending token.
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("mem0: filter syntax error at position %d: %s", e.Pos, e.Msg)
+}
+
+func (e *SyntaxError) Unwrap() error { return ErrInvalidFilter }
+
+// ParseFilters parses a filter written in the textual form, for example:
+//
+//	user_id = "u1" AND (categories contains "travel" OR created_at >= 2026-01-01)
+//
+// Conditions compare a field with a value using =, !=, >, >=, <, <=, in,
+// not in, contains or icontains, and combine with AND, OR, NOT and
+// parentheses; AND binds tighter than OR. Keywords are case-insensitive.
+// Values are double-quoted strings, numbers, true, false, bare dates
+// (2026-01-01 or RFC3339), the wildcard * or bracketed lists of these.
+// Metadata keys are addressed as metadata.<key>, or as metadata."<key>"
+// if the key has characters other than letters, digits, _ and ".".
+//
+// Filters.String formats filters back into this form.
+func ParseFilters(s string) (Filters, error) {
+	p := &filterParser{lex: filterLexer{src: s}}
+	p.next()
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.err != nil || p.tok.kind != tokEOF {
+		return nil, p.errorf("unexpected %s", p.tok)
+	}
+	if err := expr.Validate(); err != nil {
+		return nil, err
+	}
+	return expr.Filters(), nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokDate
+	tokWildcard
+	tokOp
+	tokLParen
+	tokRParen
+	tokLBracket
+	tokRBracket
+	tokComma
+)
+
+type token struct {
+	kind tokenKind
+	text string // raw text; unquoted for strings
+	pos  int    // 1-based byte offset
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of input"
+	case tokString:
+		return strconv.Quote(t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// keyword reports whether t is the case-insensitive keyword kw.
+func (t token) keyword(kw string) bool {
+	return t.kind == tokIdent && strings.EqualFold(t.text, kw)
+}
+
+var dateRE = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}(T\d{2}:\d{2}(:\d{2}(\.\d+)?)?(Z|[+-]\d{2}:\d{2})?)?$`)
+
+type filterLexer struct {
+	src string
+	off int
+}
+
+func (l *filterLexer) next() (token, error) {
+	for l.off < len(l.src) {
+		r, size := utf8.DecodeRuneInString(l.src[l.off:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		l.off += size
+	}
+	start := l.off
+	tok := token{pos: start + 1}
+	if start >= len(l.src) {
+		tok.kind = tokEOF
+		return tok, nil
+	}
+
+	c := l.src[start]
+	switch {
+	case c == '(' || c == ')' || c == '[' || c == ']' || c == ',' || c == '*':
+		l.off++
+		tok.kind = map[byte]tokenKind{
+			'(': tokLParen, ')': tokRParen, '[': tokLBracket, ']': tokRBracket, ',': tokComma, '*': tokWildcard,
+		}[c]
+		tok.text = string(c)
+		return tok, nil
+
+	case c == '=' || c == '!' || c == '<' || c == '>':
+		l.off++
+		if l.off < len(l.src) && l.src[l.off] == '=' {
+			l.off++
+		}
+		tok.kind, tok.text = tokOp, l.src[start:l.off]
+		if tok.text == "!" {
+			return tok, &SyntaxError{Pos: tok.pos, Msg: `expected "!="`}
+		}
+		return tok, nil
+
+	case c == '"':
+		end := start + 1
+		for end < len(l.src) && l.src[end] != '"' {
+			if l.src[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(l.src) {
+			return tok, &SyntaxError{Pos: tok.pos, Msg: "unterminated string"}
+		}
+		text, err := strconv.Unquote(l.src[start : end+1])
+		if err != nil {
+			return tok, &SyntaxError{Pos: tok.pos, Msg: "invalid string " + l.src[start:end+1]}
+		}
+		l.off = end + 1
+		tok.kind, tok.text = tokString, text
+		return tok, nil
+
+	case c == '-' || c >= '0' && c <= '9':
+		l.off++
+		for l.off < len(l.src) && strings.IndexByte("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ:.+-", l.src[l.off]) >= 0 {
+			l.off++
+		}
+		tok.text = l.src[start:l.off]
+		if dateRE.MatchString(tok.text) {
+			tok.kind = tokDate
+			return tok, nil
+		}
+		if _, err := strconv.ParseFloat(tok.text, 64); err != nil {
+			return tok, &SyntaxError{Pos: tok.pos, Msg: "invalid number or date " + strconv.Quote(tok.text)}
+		}
+		tok.kind = tokNumber
+		return tok, nil
+
+	case isIdentStart(c):
+		for l.off < len(l.src) && isIdentPart(l.src[l.off]) {
+			l.off++
+		}
+		tok.kind, tok.text = tokIdent, l.src[start:l.off]
+		return tok, nil
+	}
+
+	r, _ := utf8.DecodeRuneInString(l.src[start:])
+	return tok, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("unexpected character %q", r)}
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || c >= '0' && c <= '9' || c == '.'
+}
+
+// writeMetadataField writes a metadata key in the form ParseFilters reads
+// back, quoting keys that would not lex as part of an identifier.
+func writeMetadataField(b *strings.Builder, key string) {
+	b.WriteString("metadata.")
+	for i := 0; i < len(key); i++ {
+		if !isIdentPart(key[i]) {
+			b.WriteString(strconv.Quote(key))
+			return
+		}
+	}
+	b.WriteString(key)
+}
+
+type filterParser struct {
+	lex filterLexer
+	tok token
+	err error // lexer error for tok, reported when tok is consumed
+}
+
+func (p *filterParser) next() {
+	p.tok, p.err = p.lex.next()
+}
+
+func (p *filterParser) errorf(format string, args ...any) error {
+	if p.err != nil {
+		return p.err
+	}
+	return &SyntaxError{Pos: p.tok.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *filterParser) parseOr() (FilterExpr, error) {
+	return p.parseChain("OR", p.parseAnd)
+}
+
+func (p *filterParser) parseAnd() (FilterExpr, error) {
+	return p.parseChain("AND", p.parseUnary)
+}
+
+// parseChain parses operands separated by the keyword op into a single
+// group. A parenthesized group of the same operator stays nested.
+func (p *filterParser) parseChain(op string, operand func() (FilterExpr, error)) (FilterExpr, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	exprs := []FilterExpr{first}
+	for p.err == nil && p.tok.keyword(op) {
+		p.next()
+		e, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, e)
+	}
+	if len(exprs) == 1 {
+		return first, nil
+	}
+	return &Group{Op: op, Exprs: exprs}, nil
+}
+
+func (p *filterParser) parseUnary() (FilterExpr, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	switch {
+	case p.tok.keyword("NOT"):
+		p.next()
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not(e), nil
+
+	case p.tok.kind == tokLParen:
+		p.next()
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.err != nil || p.tok.kind != tokRParen {
+			return nil, p.errorf("expected \")\", found %s", p.tok)
+		}
+		p.next()
+		return e, nil
+	}
+	return p.parseCondition()
+}
+
+var textOps = map[string]string{
+	"=": OpEq, "!=": OpNe, ">": OpGt, ">=": OpGte, "<": OpLt, "<=": OpLte,
+}
+
+func (p *filterParser) parseCondition() (FilterExpr, error) {
+	if p.tok.kind != tokIdent || isFilterKeyword(p.tok.text) {
+		return nil, p.errorf("expected field name, found %s", p.tok)
+	}
+	c := &Condition{Field: p.tok.text}
+	if c.Field == "metadata." {
+		p.next()
+		if p.err != nil || p.tok.kind != tokString || p.tok.text == "" {
+			return nil, p.errorf("expected metadata key, found %s", p.tok)
+		}
+		c.Field, c.MetadataKey = "metadata", p.tok.text
+	} else if key, ok := strings.CutPrefix(c.Field, "metadata."); ok && key != "" {
+		c.Field, c.MetadataKey = "metadata", key
+	} else if strings.Contains(c.Field, ".") {
+		return nil, p.errorf("invalid field name %q; only metadata fields may contain \".\"", c.Field)
+	}
+	p.next()
+
+	if p.err != nil {
+		return nil, p.err
+	}
+	switch {
+	case p.tok.kind == tokOp:
+		c.Op = textOps[p.tok.text]
+	case p.tok.keyword("IN"):
+		c.Op = OpIn
+	case p.tok.keyword("CONTAINS"):
+		c.Op = OpContains
+	case p.tok.keyword("ICONTAINS"):
+		c.Op = OpIContains
+	case p.tok.keyword("NOT"):
+		p.next()
+		if p.err != nil || !p.tok.keyword("IN") {
+			return nil, p.errorf("expected \"in\" after \"not\", found %s", p.tok)
+		}
+		c.Op = OpNin
+	default:
+		return nil, p.errorf("expected operator after %s, found %s", c.Field, p.tok)
+	}
+	p.next()
+
+	var err error
+	if c.Op == OpIn || c.Op == OpNin {
+		c.Value, err = p.parseList()
+	} else {
+		c.Value, err = p.parseValue()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (p *filterParser) parseList() (any, error) {
+	if p.err != nil || p.tok.kind != tokLBracket {
+		return nil, p.errorf("expected \"[\", found %s", p.tok)
+	}
+	p.next()
+
+	values := []any{}
+	for p.err != nil || p.tok.kind != tokRBracket {
+		if len(values) > 0 {
+			if p.err != nil || p.tok.kind != tokComma {
+				return nil, p.errorf("expected \",\" or \"]\", found %s", p.tok)
+			}
+			p.next()
+		}
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	p.next()
+	return values, nil
+}
+
+func (p *filterParser) parseValue() (any, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	tok := p.tok
+	var v any
+	switch {
+	case tok.kind == tokString, tok.kind == tokDate:
+		v = tok.text
+	case tok.kind == tokWildcard:
+		v = Wildcard
+	case tok.kind == tokNumber:
+		if n, err := strconv.ParseInt(tok.text, 10, 64); err == nil {
+			v = n
+		} else {
+			v, _ = strconv.ParseFloat(tok.text, 64)
+		}
+	case tok.keyword("true"):
+		v = true
+	case tok.keyword("false"):
+		v = false
+	default:
+		return nil, p.errorf("expected value, found %s", tok)
+	}
+	p.next()
+	return v, nil
+}
+
+func isFilterKeyword(s string) bool {
+	switch strings.ToUpper(s) {
+	case "AND", "OR", "NOT", "IN", "CONTAINS", "ICONTAINS", "TRUE", "FALSE":
+		return true
+	}
+	return false
+}
+
+// String formats the filters in the textual form accepted by
+// ParseFilters. Filters that don't form a valid expression are formatted
+// as a plain map.
+func (f Filters) String() string {
+	if len(f) == 0 {
+		return ""
+	}
+	expr, err := f.Expr()
+	if err != nil {
+		return fmt.Sprint(map[string]any(f))
+	}
+	var b strings.Builder
+	writeFilterExpr(&b, expr)
+	return b.String()
+}
+
+var opText = map[string]string{
+	OpEq: "=", OpNe: "!=", OpGt: ">", OpGte: ">=", OpLt: "<", OpLte: "<=",
+	OpIn: "in", OpNin: "not in", OpContains: "contains", OpIContains: "icontains",
+}
+
+func writeFilterExpr(b *strings.Builder, expr FilterExpr) {
+	switch e := expr.(type) {
+	case *Condition:
+		if e.MetadataKey != "" {
+			writeMetadataField(b, e.MetadataKey)
+		} else {
+			b.WriteString(e.Field)
+		}
+		op, ok := opText[e.Op]
+		if !ok {
+			op = e.Op
+		}
+		b.WriteString(" " + op + " ")
+		writeFilterValue(b, e.Value)
+
+	case *Group:
+		if e.Op == "NOT" {
+			b.WriteString("NOT ")
+			if len(e.Exprs) == 1 {
+				writeFilterOperand(b, e.Exprs[0])
+				return
+			}
+			// NOT of several expressions matches when none of them do.
+			writeFilterOperand(b, Or(e.Exprs...))
+			return
+		}
+		for i, sub := range e.Exprs {
+			if i > 0 {
+				b.WriteString(" " + e.Op + " ")
+			}
+			writeFilterOperand(b, sub)
+		}
+	}
+}
+
+// writeFilterOperand writes expr, parenthesizing AND and OR groups so the
+// output parses back to the same tree.
+func writeFilterOperand(b *strings.Builder, expr FilterExpr) {
+	if g, ok := expr.(*Group); ok && g.Op != "NOT" {
+		b.WriteByte('(')
+		writeFilterExpr(b, expr)
+		b.WriteByte(')')
+		return
+	}
+	writeFilterExpr(b, expr)
+}
+
+func writeFilterValue(b *strings.Builder, v any) {
+	switch v := v.(type) {
+	case string:
+		if v == Wildcard || dateRE.MatchString(v) {
+			b.WriteString(v)
+		} else {
+			b.WriteString(strconv.Quote(v))
+		}
+	case float64:
+		b.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+	case []any:
+		b.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			writeFilterValue(b, item)
+		}
+		b.WriteByte(']')
+	default:
+		fmt.Fprint(b, v)
+	}
+}
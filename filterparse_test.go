@@ -0,0 +1,112 @@
+package mem0
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseFilters(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{
+			`user_id = "u1" AND (categories contains "travel" OR created_at >= 2026-01-01)`,
+			`{"AND":[{"user_id":"u1"},{"OR":[{"categories":{"contains":"travel"}},{"created_at":{"gte":"2026-01-01"}}]}]}`,
+		},
+		{`agent_id != "a1"`, `{"agent_id":{"ne":"a1"}}`},
+		{`run_id = *`, `{"run_id":"*"}`},
+		{`categories in ["travel", "food"]`, `{"categories":{"in":["travel","food"]}}`},
+		{`user_id NOT IN ["u2"]`, `{"user_id":{"nin":["u2"]}}`},
+		{`metadata.score > 4.5 and metadata.vip = true`, `{"AND":[{"metadata":{"score":{"gt":4.5}}},{"metadata":{"vip":true}}]}`},
+		{`not memory icontains "ramen"`, `{"NOT":[{"memory":{"icontains":"ramen"}}]}`},
+		{`a = 1 OR b = 2 AND c = 3`, `{"OR":[{"a":1},{"AND":[{"b":2},{"c":3}]}]}`},
+		{`created_at < 2026-03-01T12:00:00Z`, `{"created_at":{"lt":"2026-03-01T12:00:00Z"}}`},
+	}
+
+	for _, tt := range tests {
+		f, err := ParseFilters(tt.input)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.input, err)
+			continue
+		}
+		data, _ := json.Marshal(f)
+		if string(data) != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.input, tt.want, data)
+		}
+	}
+}
+
+func TestParseFiltersErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		pos   int
+	}{
+		{`user_id = `, 11},
+		{`user_id "u1"`, 9},
+		{`(user_id = "u1"`, 16},
+		{`user_id = "u1`, 11},
+		{`user_id = "u1" AND`, 19},
+		{`user_id ! "u1"`, 9},
+		{`user_id = "u1" user_id`, 16},
+		{`categories in "travel"`, 15},
+		{`user_id = 12abc`, 11},
+		{`user_id = "u1" & agent_id = "a1"`, 16},
+		{`metadata. = "gold"`, 11},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseFilters(tt.input)
+		var syn *SyntaxError
+		if !errors.As(err, &syn) {
+			t.Errorf("%s: expected SyntaxError, got %v", tt.input, err)
+			continue
+		}
+		if syn.Pos != tt.pos {
+			t.Errorf("%s: expected position %d, got %d (%v)", tt.input, tt.pos, syn.Pos, err)
+		}
+		if !errors.Is(err, ErrInvalidFilter) {
+			t.Errorf("%s: expected error to wrap ErrInvalidFilter", tt.input)
+		}
+	}
+
+	if _, err := ParseFilters(`categories in []`); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("expected ErrInvalidFilter for empty list, got %v", err)
+	}
+}
+
+func TestFiltersStringRoundTrip(t *testing.T) {
+	inputs := []string{
+		`user_id = "u1" AND (categories contains "travel" OR created_at >= 2026-01-01)`,
+		`NOT (agent_id = * OR app_id != "x")`,
+		`metadata.tier in ["gold", "silver"] AND metadata.score <= 10`,
+		`(a = 1 AND b = 2) OR memory icontains "say \"hi\""`,
+		`user_id not in ["u2", "u3"]`,
+		`metadata."tier-level" = "gold" OR metadata."home city" != "Lisbon"`,
+	}
+
+	for _, in := range inputs {
+		f, err := ParseFilters(in)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", in, err)
+			continue
+		}
+		if got := f.String(); got != in {
+			t.Errorf("expected %s, got %s", in, got)
+		}
+	}
+
+	for _, f := range []Filters{
+		NewFilters().WithUserID("u1").Or(NewFilters().WithCategories("travel")),
+		Metadata("tier-level").Eq("gold").Filters(),
+	} {
+		again, err := ParseFilters(f.String())
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", f, err)
+		}
+		if again.String() != f.String() {
+			t.Errorf("expected %s, got %s", f, again)
+		}
+	}
+}
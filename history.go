@@ -0,0 +1,227 @@
+package mem0
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+)
+
+// HistoryEvent is the kind of change recorded in a MemoryHistory entry.
+type HistoryEvent string
+
+const (
+	EventAdd    HistoryEvent = "ADD"
+	EventUpdate HistoryEvent = "UPDATE"
+	EventDelete HistoryEvent = "DELETE"
+)
+
+// HistoryVersion is one entry of a memory's timeline.
+type HistoryVersion struct {
+	MemoryID string
+	Event    HistoryEvent
+	At       time.Time
+	// Previous is the memory text before the change and Text the text
+	// after it. Text is empty after a delete.
+	Previous string
+	Text     string
+	// Diff is the word-level change from Previous to Text.
+	Diff Diff
+}
+
+// Timeline is a memory's history in chronological order.
+type Timeline struct {
+	MemoryID string
+	Versions []HistoryVersion
+}
+
+// NewTimeline orders history entries of one memory by time and computes the
+// text and diff of each version. Entries whose OldMemory is empty take the
+// previous version's text as their starting point.
+func NewTimeline(history []MemoryHistory) *Timeline {
+	entries := slices.Clone(history)
+	slices.SortStableFunc(entries, func(a, b MemoryHistory) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	t := &Timeline{Versions: make([]HistoryVersion, 0, len(entries))}
+	var text string
+	for _, h := range entries {
+		if t.MemoryID == "" {
+			t.MemoryID = h.MemoryID
+		}
+		v := HistoryVersion{MemoryID: h.MemoryID, Event: h.Event, At: h.CreatedAt, Previous: text}
+		if h.OldMemory != "" {
+			v.Previous = h.OldMemory
+		}
+		switch h.Event {
+		case EventDelete:
+			v.Text = ""
+		case EventAdd:
+			v.Previous = ""
+			v.Text = h.NewMemory
+		default:
+			v.Text = h.NewMemory
+		}
+		v.Diff = DiffWords(v.Previous, v.Text)
+		text = v.Text
+		t.Versions = append(t.Versions, v)
+	}
+	return t
+}
+
+// At returns the memory text as of when. ok is false if the memory had not
+// been added yet or had been deleted by then.
+func (t *Timeline) At(when time.Time) (text string, ok bool) {
+	// Index of the first version after when.
+	i, _ := slices.BinarySearchFunc(t.Versions, when, func(v HistoryVersion, when time.Time) int {
+		if v.At.After(when) {
+			return 1
+		}
+		return -1
+	})
+	if i == 0 {
+		return "", false
+	}
+	v := t.Versions[i-1]
+	if v.Event == EventDelete {
+		return "", false
+	}
+	return v.Text, true
+}
+
+// Timeline fetches a memory's history and returns it as a Timeline.
+func (c *Client) Timeline(ctx context.Context, memoryID string) (*Timeline, error) {
+	history, err := c.GetMemoryHistory(ctx, memoryID)
+	if err != nil {
+		return nil, err
+	}
+	t := NewTimeline(history)
+	t.MemoryID = memoryID
+	return t, nil
+}
+
+// MergeTimelines interleaves the versions of several timelines into one
+// chronological feed. Versions at the same instant keep the order of the
+// timelines given.
+func MergeTimelines(timelines ...*Timeline) []HistoryVersion {
+	var feed []HistoryVersion
+	for _, t := range timelines {
+		feed = append(feed, t.Versions...)
+	}
+	slices.SortStableFunc(feed, func(a, b HistoryVersion) int {
+		return a.At.Compare(b.At)
+	})
+	return feed
+}
+
+// LiveHistoryFeed fetches the history of every memory matching filters,
+// for example all of a user's memories, and merges it into one
+// chronological feed. Only memories that still exist are listed, so the
+// history of deleted memories, including their EventDelete entries, is
+// not included.
+func (c *Client) LiveHistoryFeed(ctx context.Context, filters Filters, opts ...PageOption) ([]HistoryVersion, error) {
+	var ids []string
+	for m, err := range c.AllMemories(ctx, filters, opts...) {
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, m.ID)
+	}
+
+	timelines := make([]*Timeline, len(ids))
+	err := forEachLimit(ctx, len(ids), defaultParallelism, func(ctx context.Context, i int) error {
+		t, err := c.Timeline(ctx, ids[i])
+		if err != nil {
+			return err
+		}
+		timelines[i] = t
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return MergeTimelines(timelines...), nil
+}
+
+// DiffKind classifies a run of words in a Diff.
+type DiffKind int
+
+const (
+	DiffEqual DiffKind = iota
+	DiffInsert
+	DiffDelete
+)
+
+// DiffOp is a run of words that are unchanged, inserted or deleted.
+type DiffOp struct {
+	Kind DiffKind
+	Text string
+}
+
+// Diff is a word-level difference between two texts.
+type Diff []DiffOp
+
+// String renders the diff inline, marking deletions as [-words-] and
+// insertions as {+words+}.
+func (d Diff) String() string {
+	parts := make([]string, len(d))
+	for i, op := range d {
+		switch op.Kind {
+		case DiffInsert:
+			parts[i] = "{+" + op.Text + "+}"
+		case DiffDelete:
+			parts[i] = "[-" + op.Text + "-]"
+		default:
+			parts[i] = op.Text
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// DiffWords returns the word-level difference from before to after,
+// splitting on whitespace. Where words are replaced the deletion comes
+// first.
+func DiffWords(before, after string) Diff {
+	a, b := strings.Fields(before), strings.Fields(after)
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var d Diff
+	add := func(kind DiffKind, word string) {
+		if n := len(d); n > 0 && d[n-1].Kind == kind {
+			d[n-1].Text += " " + word
+			return
+		}
+		d = append(d, DiffOp{Kind: kind, Text: word})
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			add(DiffEqual, a[i])
+			i, j = i+1, j+1
+		case j == len(b) || i < len(a) && lcs[i+1][j] >= lcs[i][j+1]:
+			add(DiffDelete, a[i])
+			i++
+		default:
+			add(DiffInsert, b[j])
+			j++
+		}
+	}
+	return d
+}
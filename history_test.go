@@ -0,0 +1,95 @@
+package mem0
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiffWords(t *testing.T) {
+	tests := []struct {
+		before, after string
+		want          string
+	}{
+		{"Likes pizza", "Likes spicy ramen", "Likes [-pizza-] {+spicy ramen+}"},
+		{"Lives in Lisbon now", "Lives in Lisbon", "Lives in Lisbon [-now-]"},
+		{"", "Allergic to peanuts", "{+Allergic to peanuts+}"},
+		{"same  text", "same text", "same text"},
+	}
+	for _, tt := range tests {
+		if got := DiffWords(tt.before, tt.after).String(); got != tt.want {
+			t.Errorf("DiffWords(%q, %q): expected %q, got %q", tt.before, tt.after, tt.want, got)
+		}
+	}
+}
+
+var historyDay = time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+var ramenHistory = []MemoryHistory{
+	// Out of order, as a client should not rely on the server's ordering.
+	{MemoryID: "m1", Event: EventUpdate, OldMemory: "Likes pizza", NewMemory: "Likes ramen", CreatedAt: historyDay.AddDate(0, 0, 2)},
+	{MemoryID: "m1", Event: EventAdd, NewMemory: "Likes pizza", CreatedAt: historyDay},
+	{MemoryID: "m1", Event: EventDelete, OldMemory: "Likes ramen", CreatedAt: historyDay.AddDate(0, 0, 5)},
+}
+
+func TestTimeline(t *testing.T) {
+	tl := NewTimeline(ramenHistory)
+	if tl.MemoryID != "m1" || len(tl.Versions) != 3 {
+		t.Fatalf("expected 3 versions of m1, got %+v", tl)
+	}
+	if v := tl.Versions[1]; v.Event != EventUpdate || v.Diff.String() != "Likes [-pizza-] {+ramen+}" {
+		t.Errorf("expected update diff, got %+v", v)
+	}
+	if v := tl.Versions[2]; v.Previous != "Likes ramen" || v.Text != "" {
+		t.Errorf("expected delete of Likes ramen, got %+v", v)
+	}
+
+	tests := []struct {
+		at   time.Time
+		want string
+		ok   bool
+	}{
+		{historyDay.Add(-time.Hour), "", false},
+		{historyDay, "Likes pizza", true},
+		{historyDay.AddDate(0, 0, 3), "Likes ramen", true},
+		{historyDay.AddDate(0, 0, 6), "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := tl.At(tt.at); got != tt.want || ok != tt.ok {
+			t.Errorf("At(%v): expected %q, %v; got %q, %v", tt.at, tt.want, tt.ok, got, ok)
+		}
+	}
+}
+
+func TestLiveHistoryFeed(t *testing.T) {
+	histories := map[string][]MemoryHistory{
+		"m1": ramenHistory[:2],
+		"m2": {{MemoryID: "m2", Event: EventAdd, NewMemory: "Lives in Lisbon", CreatedAt: historyDay.AddDate(0, 0, 1)}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/v1/memories/"), "/history/"); ok {
+			json.NewEncoder(w).Encode(histories[id])
+			return
+		}
+		json.NewEncoder(w).Encode([]Memory{{ID: "m1"}, {ID: "m2"}})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL))
+	feed, err := client.LiveHistoryFeed(context.Background(), NewFilters().WithUserID("u1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, v := range feed {
+		got = append(got, v.MemoryID+":"+string(v.Event))
+	}
+	if strings.Join(got, ",") != "m1:ADD,m2:ADD,m1:UPDATE" {
+		t.Errorf("expected chronological feed, got %v", got)
+	}
+}
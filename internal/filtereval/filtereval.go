@@ -0,0 +1,346 @@
+// Package filtereval evaluates mem0 v2 filter documents against a record.
+//
+// A filter is a JSON-like object. The keys "AND", "OR" and "NOT" take a list
+// of sub-filters; "NOT" matches when none of its sub-filters match. Any
+// other key names a field, and sibling keys are combined with AND. A field
+// condition is either a plain value (equality, or membership for list
+// fields), the wildcard "*" (field is set), or an object of operators: eq,
+// ne, in, nin, gt, gte, lt, lte, contains and icontains. The "metadata" key
+// takes an object of metadata field conditions.
+package filtereval
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Getter returns the value of a record field and whether it is set.
+// Timestamps should be returned as time.Time and list fields as []string or
+// []any.
+type Getter func(field string) (any, bool)
+
+// Match reports whether the record exposed by get satisfies filter. It
+// returns an error for malformed filters or unknown operators.
+func Match(filter any, get Getter) (bool, error) {
+	m, ok := normalize(filter).(map[string]any)
+	if !ok {
+		if filter == nil {
+			return true, nil
+		}
+		return false, fmt.Errorf("filter must be an object, got %T", filter)
+	}
+	return matchObject(m, get)
+}
+
+func matchObject(m map[string]any, get Getter) (bool, error) {
+	for key, cond := range m {
+		ok, err := matchKey(key, cond, get)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func matchKey(key string, cond any, get Getter) (bool, error) {
+	switch key {
+	case "AND", "OR", "NOT":
+		subs, err := subFilters(key, cond)
+		if err != nil {
+			return false, err
+		}
+		for _, sub := range subs {
+			ok, err := matchObject(sub, get)
+			if err != nil {
+				return false, err
+			}
+			switch {
+			case key == "AND" && !ok:
+				return false, nil
+			case key == "OR" && ok:
+				return true, nil
+			case key == "NOT" && ok:
+				return false, nil
+			}
+		}
+		return key != "OR", nil
+
+	case "metadata":
+		conds, ok := cond.(map[string]any)
+		if !ok {
+			return false, fmt.Errorf("metadata filter must be an object, got %T", cond)
+		}
+		md, _ := get("metadata")
+		mdMap, _ := normalize(md).(map[string]any)
+		for field, c := range conds {
+			v, set := mdMap[field]
+			ok, err := matchField("metadata."+field, v, set, c)
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+
+	v, set := get(key)
+	return matchField(key, normalize(v), set, cond)
+}
+
+func subFilters(key string, cond any) ([]map[string]any, error) {
+	switch c := cond.(type) {
+	case map[string]any:
+		return []map[string]any{c}, nil
+	case []any:
+		subs := make([]map[string]any, 0, len(c))
+		for _, s := range c {
+			m, ok := s.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%s: sub-filter must be an object, got %T", key, s)
+			}
+			subs = append(subs, m)
+		}
+		return subs, nil
+	}
+	return nil, fmt.Errorf("%s: expected a list of filters, got %T", key, cond)
+}
+
+func matchField(field string, v any, set bool, cond any) (bool, error) {
+	if s, ok := cond.(string); ok && s == "*" {
+		return set && !isEmpty(v), nil
+	}
+
+	ops, ok := cond.(map[string]any)
+	if !ok {
+		return equalOrContains(v, set, cond), nil
+	}
+
+	for op, arg := range ops {
+		ok, err := applyOp(field, op, v, set, arg)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func applyOp(field, op string, v any, set bool, arg any) (bool, error) {
+	switch op {
+	case "eq":
+		return equalOrContains(v, set, arg), nil
+	case "ne":
+		return !equalOrContains(v, set, arg), nil
+	case "in", "nin":
+		list, ok := arg.([]any)
+		if !ok {
+			return false, fmt.Errorf("%s: %q expects a list, got %T", field, op, arg)
+		}
+		found := false
+		for _, want := range list {
+			if equalOrContains(v, set, want) {
+				found = true
+				break
+			}
+		}
+		return found == (op == "in"), nil
+	case "contains", "icontains":
+		want, ok := arg.(string)
+		if !ok {
+			return false, fmt.Errorf("%s: %q expects a string, got %T", field, op, arg)
+		}
+		return contains(v, want, op == "icontains"), nil
+	case "gt", "gte", "lt", "lte":
+		if !set || v == nil {
+			return false, nil
+		}
+		c, err := compare(v, arg)
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", field, err)
+		}
+		switch op {
+		case "gt":
+			return c > 0, nil
+		case "gte":
+			return c >= 0, nil
+		case "lt":
+			return c < 0, nil
+		}
+		return c <= 0, nil
+	}
+	return false, fmt.Errorf("%s: unknown operator %q", field, op)
+}
+
+// equalOrContains compares a scalar field for equality, or checks
+// membership when the field is a list.
+func equalOrContains(v any, set bool, want any) bool {
+	if !set {
+		return want == nil
+	}
+	if list, ok := v.([]any); ok {
+		return slices.ContainsFunc(list, func(e any) bool { return equal(e, want) })
+	}
+	return equal(v, want)
+}
+
+func contains(v any, want string, fold bool) bool {
+	match := func(s string) bool {
+		if fold {
+			return strings.Contains(strings.ToLower(s), strings.ToLower(want))
+		}
+		return strings.Contains(s, want)
+	}
+
+	switch t := v.(type) {
+	case string:
+		return match(t)
+	case []any:
+		for _, e := range t {
+			s, ok := e.(string)
+			if !ok {
+				continue
+			}
+			if fold && strings.EqualFold(s, want) || !fold && s == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func equal(a, b any) bool {
+	if c, err := compare(a, b); err == nil {
+		return c == 0
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// compare orders two scalars. Times are compared with times or with
+// strings in RFC 3339 or YYYY-MM-DD form; numbers with numbers.
+func compare(a, b any) (int, error) {
+	if ta, ok := a.(time.Time); ok {
+		tb, err := toTime(b)
+		if err != nil {
+			return 0, err
+		}
+		return ta.Compare(tb), nil
+	}
+	if fa, ok := toFloat(a); ok {
+		fb, ok := toFloat(b)
+		if !ok {
+			return 0, fmt.Errorf("cannot compare number with %T", b)
+		}
+		switch {
+		case fa < fb:
+			return -1, nil
+		case fa > fb:
+			return 1, nil
+		}
+		return 0, nil
+	}
+	if sa, ok := a.(string); ok {
+		sb, ok := b.(string)
+		if !ok {
+			return 0, fmt.Errorf("cannot compare string with %T", b)
+		}
+		return strings.Compare(sa, sb), nil
+	}
+	if ba, ok := a.(bool); ok {
+		bb, ok := b.(bool)
+		if !ok || ba != bb {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return 0, fmt.Errorf("cannot compare %T", a)
+}
+
+func toTime(v any) (time.Time, error) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, nil
+	case string:
+		if ts, err := time.Parse(time.RFC3339, t); err == nil {
+			return ts, nil
+		}
+		if ts, err := time.Parse(time.DateOnly, t); err == nil {
+			return ts, nil
+		}
+		return time.Time{}, fmt.Errorf("invalid time %q", t)
+	}
+	return time.Time{}, fmt.Errorf("cannot compare time with %T", v)
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func isEmpty(v any) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case string:
+		return t == ""
+	case []any:
+		return len(t) == 0
+	}
+	return false
+}
+
+// normalize converts named map and slice types, such as mem0.Filters and
+// []mem0.Filters, into map[string]any and []any so the evaluator only deals
+// with the shapes produced by encoding/json.
+func normalize(v any) any {
+	switch t := v.(type) {
+	case nil, string, bool, float64, time.Time:
+		return v
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for k, e := range t {
+			out[k] = normalize(e)
+		}
+		return out
+	case []any:
+		out := make([]any, len(t))
+		for i, e := range t {
+			out[i] = normalize(e)
+		}
+		return out
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return v
+		}
+		out := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			out[iter.Key().String()] = normalize(iter.Value().Interface())
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		out := make([]any, rv.Len())
+		for i := range out {
+			out[i] = normalize(rv.Index(i).Interface())
+		}
+		return out
+	case reflect.String:
+		return rv.String()
+	}
+	return v
+}
@@ -0,0 +1,87 @@
+package filtereval
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestMatch(t *testing.T) {
+	record := map[string]any{
+		"user_id":    "u1",
+		"categories": []string{"travel", "Food"},
+		"created_at": time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		"memory":     "Enjoys Hiking",
+		"metadata":   map[string]any{"tier": "gold", "score": 7},
+	}
+	get := func(field string) (any, bool) {
+		v, ok := record[field]
+		return v, ok
+	}
+
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{`{"user_id": "u1"}`, true},
+		{`{"user_id": "u2"}`, false},
+		{`{"user_id": "*"}`, true},
+		{`{"agent_id": "*"}`, false},
+		{`{"user_id": {"ne": "u2"}}`, true},
+		{`{"user_id": {"in": ["u2", "u1"]}}`, true},
+		{`{"user_id": {"nin": ["u1"]}}`, false},
+		{`{"categories": {"in": ["food", "travel"]}}`, true},
+		{`{"categories": {"contains": "food"}}`, false},
+		{`{"categories": {"icontains": "food"}}`, true},
+		{`{"memory": {"icontains": "hiking"}}`, true},
+		{`{"created_at": {"gte": "2026-01-01", "lte": "2026-12-31T00:00:00Z"}}`, true},
+		{`{"created_at": {"gt": "2026-03-01T00:00:00Z"}}`, false},
+		{`{"metadata": {"tier": "gold"}}`, true},
+		{`{"metadata": {"score": {"gte": 5}}}`, true},
+		{`{"AND": [{"user_id": "u1"}, {"categories": {"in": ["travel"]}}]}`, true},
+		{`{"OR": [{"user_id": "u2"}, {"categories": {"in": ["health"]}}]}`, false},
+		{`{"NOT": [{"user_id": "u2"}]}`, true},
+		{`{"NOT": [{"user_id": "u1"}]}`, false},
+	}
+
+	for _, tt := range tests {
+		var filter map[string]any
+		if err := json.Unmarshal([]byte(tt.filter), &filter); err != nil {
+			t.Fatalf("%s: invalid test filter: %v", tt.filter, err)
+		}
+		got, err := Match(filter, get)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.filter, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.filter, tt.want, got)
+		}
+	}
+}
+
+func TestMatchErrors(t *testing.T) {
+	get := func(string) (any, bool) { return "x", true }
+
+	for _, filter := range []any{
+		map[string]any{"user_id": map[string]any{"between": 1}},
+		map[string]any{"AND": "nope"},
+		map[string]any{"user_id": map[string]any{"in": "u1"}},
+	} {
+		if _, err := Match(filter, get); err == nil {
+			t.Errorf("%v: expected error", filter)
+		}
+	}
+}
+
+type namedFilters map[string]any
+
+func TestMatchNamedTypes(t *testing.T) {
+	get := func(field string) (any, bool) { return "u1", field == "user_id" }
+	filter := namedFilters{"OR": []namedFilters{{"user_id": "u2"}, {"user_id": "u1"}}}
+
+	ok, err := Match(filter, get)
+	if err != nil || !ok {
+		t.Errorf("expected match for named map types, got %v, %v", ok, err)
+	}
+}
@@ -0,0 +1,37 @@
+package fsutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.json")
+
+	for _, want := range []string{"first", "second"} {
+		if err := WriteAtomic(path, []byte(want)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(got) != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("expected temporary files to be removed, got %d entries", len(entries))
+	}
+}
+
+func TestWriteAtomicMissingDir(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "data.json")
+	if err := WriteAtomic(path, []byte("x")); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,301 @@
+// Package memstore holds the in-memory state shared by the offline
+// backends: memories in insertion order, their change history, and the
+// platform's rules for adding, updating and deleting them.
+//
+// A Store is not safe for concurrent use; callers serialize access.
+package memstore
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	mem0 "github.com/alcova-ai/mem0-go"
+)
+
+// Record is a stored memory with its embedding, if any.
+type Record struct {
+	mem0.Memory
+	Embedding []float32 `json:"embedding,omitempty"`
+}
+
+// State is the serializable form of a Store.
+type State struct {
+	Seq      int                             `json:"seq"`
+	Memories []*Record                       `json:"memories"`
+	History  map[string][]mem0.MemoryHistory `json:"history"`
+}
+
+// Store holds memories and their history.
+type Store struct {
+	now      func() time.Time
+	seq      int
+	memories map[string]*Record
+	order    []string // memory IDs in insertion order
+	history  map[string][]mem0.MemoryHistory
+}
+
+// New returns an empty store that timestamps changes with now.
+func New(now func() time.Time) *Store {
+	return &Store{
+		now:      now,
+		memories: make(map[string]*Record),
+		history:  make(map[string][]mem0.MemoryHistory),
+	}
+}
+
+// FromState returns a store holding st.
+func FromState(st State, now func() time.Time) *Store {
+	s := New(now)
+	s.seq = st.Seq
+	for _, r := range st.Memories {
+		s.memories[r.ID] = r
+		s.order = append(s.order, r.ID)
+	}
+	if st.History != nil {
+		s.history = st.History
+	}
+	return s
+}
+
+// State returns the store's contents for serialization. It shares memory
+// with the store.
+func (s *Store) State() State {
+	st := State{Seq: s.seq, History: s.history}
+	for _, id := range s.order {
+		st.Memories = append(st.Memories, s.memories[id])
+	}
+	return st
+}
+
+// Clone returns a copy of the store that changes to s do not affect.
+func (s *Store) Clone() *Store {
+	c := &Store{
+		now:      s.now,
+		seq:      s.seq,
+		memories: make(map[string]*Record, len(s.memories)),
+		order:    slices.Clone(s.order),
+		history:  make(map[string][]mem0.MemoryHistory, len(s.history)),
+	}
+	for id, r := range s.memories {
+		c.memories[id] = &Record{Memory: CloneMemory(&r.Memory), Embedding: r.Embedding}
+	}
+	for id, h := range s.history {
+		c.history[id] = slices.Clone(h)
+	}
+	return c
+}
+
+// NextID returns a new ID with the given prefix.
+func (s *Store) NextID(prefix string) string {
+	s.seq++
+	return fmt.Sprintf("%s-%d", prefix, s.seq)
+}
+
+// Get returns the stored record for id.
+func (s *Store) Get(id string) (*Record, bool) {
+	r, ok := s.memories[id]
+	return r, ok
+}
+
+// All returns every stored record in insertion order.
+func (s *Store) All() []*Record {
+	out := make([]*Record, len(s.order))
+	for i, id := range s.order {
+		out[i] = s.memories[id]
+	}
+	return out
+}
+
+// Insert stores m and records an ADD history entry. Memories without an ID
+// are assigned one, and zero timestamps are set to the current time.
+func (s *Store) Insert(m mem0.Memory, embedding []float32) *Record {
+	now := s.now()
+	if m.ID == "" {
+		m.ID = s.NextID("mem")
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = now
+	}
+	if m.UpdatedAt.IsZero() {
+		m.UpdatedAt = m.CreatedAt
+	}
+	if m.Hash == "" {
+		m.Hash = hashText(m.Memory)
+	}
+
+	r := &Record{Memory: CloneMemory(&m), Embedding: embedding}
+	s.memories[m.ID] = r
+	s.order = append(s.order, m.ID)
+	s.record(&r.Memory, mem0.MemoryHistory{Event: mem0.EventAdd, NewMemory: m.Memory})
+	return r
+}
+
+// Update applies a text and metadata change and records an UPDATE history
+// entry. embedding replaces the record's embedding when text is set. It
+// returns false without changing anything if the memory is immutable.
+func (s *Store) Update(r *Record, text string, metadata map[string]any, embedding []float32) bool {
+	if r.Immutable {
+		return false
+	}
+
+	old := r.Memory.Memory
+	if text != "" {
+		r.Memory.Memory = text
+		r.Hash = hashText(text)
+		r.Embedding = embedding
+	}
+	if metadata != nil {
+		r.Metadata = metadata
+	}
+	r.UpdatedAt = s.now()
+	s.record(&r.Memory, mem0.MemoryHistory{Event: mem0.EventUpdate, OldMemory: old, NewMemory: r.Memory.Memory})
+	return true
+}
+
+// Remove deletes a memory and records a DELETE history entry. It returns
+// false if the memory does not exist.
+func (s *Store) Remove(id string) bool {
+	r, ok := s.memories[id]
+	if !ok {
+		return false
+	}
+	s.record(&r.Memory, mem0.MemoryHistory{Event: mem0.EventDelete, OldMemory: r.Memory.Memory})
+	delete(s.memories, id)
+	s.order = slices.DeleteFunc(s.order, func(o string) bool { return o == id })
+	return true
+}
+
+func (s *Store) record(m *mem0.Memory, h mem0.MemoryHistory) {
+	now := s.now()
+	h.ID = s.NextID("hist")
+	h.MemoryID = m.ID
+	h.UserID = m.UserID
+	h.CreatedAt = now
+	h.UpdatedAt = now
+	s.history[m.ID] = append(s.history[m.ID], h)
+}
+
+// History returns every recorded change to a memory, including memories
+// that have since been deleted.
+func (s *Store) History(id string) ([]mem0.MemoryHistory, bool) {
+	h, ok := s.history[id]
+	return slices.Clone(h), ok
+}
+
+// Duplicate reports whether a memory with the same text already exists in
+// the request's scope.
+func (s *Store) Duplicate(req *mem0.AddMemoriesRequest, text string) bool {
+	for _, r := range s.memories {
+		if r.Memory.Memory == text && r.UserID == req.UserID && r.AgentID == req.AgentID &&
+			r.AppID == req.AppID && r.RunID == req.RunID {
+			return true
+		}
+	}
+	return false
+}
+
+// Match returns copies of the records satisfying filters in insertion
+// order. The copies are safe to use without serializing access.
+func (s *Store) Match(filters mem0.Filters) ([]Record, error) {
+	var out []Record
+	for _, id := range s.order {
+		r := s.memories[id]
+		ok, err := filters.Match(r.Memory)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			out = append(out, Record{Memory: CloneMemory(&r.Memory), Embedding: r.Embedding})
+		}
+	}
+	return out, nil
+}
+
+// Entities derives the users, agents, apps and runs that own at least one
+// memory, sorted by type and ID. An empty typ returns every type.
+func (s *Store) Entities(typ mem0.EntityType) []mem0.Entity {
+	byKey := map[string]*mem0.Entity{}
+	var keys []string
+	for _, id := range s.order {
+		m := &s.memories[id].Memory
+		for t, name := range scopes(m) {
+			if name == "" || typ != "" && t != typ {
+				continue
+			}
+			key := string(t) + "/" + name
+			e, ok := byKey[key]
+			if !ok {
+				e = &mem0.Entity{ID: name, Name: name, Type: string(t), CreatedAt: m.CreatedAt}
+				byKey[key] = e
+				keys = append(keys, key)
+			}
+			e.TotalMemories++
+			if m.UpdatedAt.After(e.UpdatedAt) {
+				e.UpdatedAt = m.UpdatedAt
+			}
+		}
+	}
+
+	slices.Sort(keys)
+	out := make([]mem0.Entity, len(keys))
+	for i, k := range keys {
+		out[i] = *byKey[k]
+	}
+	return out
+}
+
+// EntityMemories returns the IDs of the memories owned by an entity, in
+// insertion order.
+func (s *Store) EntityMemories(typ mem0.EntityType, id string) []string {
+	var ids []string
+	for _, mid := range s.order {
+		if scopes(&s.memories[mid].Memory)[typ] == id {
+			ids = append(ids, mid)
+		}
+	}
+	return ids
+}
+
+func scopes(m *mem0.Memory) map[mem0.EntityType]string {
+	return map[mem0.EntityType]string{
+		mem0.EntityTypeUser:  m.UserID,
+		mem0.EntityTypeAgent: m.AgentID,
+		mem0.EntityTypeApp:   m.AppID,
+		mem0.EntityTypeRun:   m.RunID,
+	}
+}
+
+// Paginate returns the requested page of items along with the effective
+// page and page size. A zero page and size returns every item.
+func Paginate[T any](items []T, page, size int) ([]T, int, int) {
+	if page <= 0 && size <= 0 {
+		return items, 0, 0
+	}
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = max(len(items), 1)
+	}
+	start := min((page-1)*size, len(items))
+	end := min(start+size, len(items))
+	return items[start:end], page, size
+}
+
+// CloneMemory returns a copy of m that does not share its categories or
+// metadata map.
+func CloneMemory(m *mem0.Memory) mem0.Memory {
+	c := *m
+	c.Categories = slices.Clone(m.Categories)
+	c.Metadata = maps.Clone(m.Metadata)
+	return c
+}
+
+func hashText(text string) string {
+	sum := md5.Sum([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,61 @@
+package memstore
+
+import (
+	"testing"
+	"time"
+
+	mem0 "github.com/alcova-ai/mem0-go"
+)
+
+func TestCloneIsIndependent(t *testing.T) {
+	s := New(time.Now)
+	r := s.Insert(mem0.Memory{Memory: "Likes ramen", UserID: "u1", Metadata: map[string]any{"source": "chat"}}, nil)
+
+	c := s.Clone()
+	s.Update(r, "Likes pho", map[string]any{"source": "import"}, nil)
+	s.Insert(mem0.Memory{Memory: "Lives in Lisbon", UserID: "u1"}, nil)
+
+	got, _ := c.Get(r.ID)
+	if got.Memory.Memory != "Likes ramen" || got.Metadata["source"] != "chat" {
+		t.Errorf("expected clone to keep the original memory, got %+v", got.Memory)
+	}
+	if n := len(c.All()); n != 1 {
+		t.Errorf("expected 1 memory in clone, got %d", n)
+	}
+	if h, _ := c.History(r.ID); len(h) != 1 {
+		t.Errorf("expected only the ADD in the clone's history, got %+v", h)
+	}
+}
+
+func TestUpdateImmutable(t *testing.T) {
+	s := New(time.Now)
+	r := s.Insert(mem0.Memory{Memory: "Allergic to peanuts", UserID: "u1", Immutable: true}, nil)
+	if s.Update(r, "Not allergic", nil, nil) {
+		t.Error("expected update of an immutable memory to be refused")
+	}
+	if h, _ := s.History(r.ID); len(h) != 1 || r.Memory.Memory != "Allergic to peanuts" {
+		t.Errorf("expected no change, got %+v and %+v", r.Memory, h)
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	tests := []struct {
+		page, size         int
+		want               []int
+		wantPage, wantSize int
+	}{
+		{0, 0, items, 0, 0},
+		{2, 2, []int{3, 4}, 2, 2},
+		{3, 2, []int{5}, 3, 2},
+		{4, 2, []int{}, 4, 2},
+		{1, 0, items, 1, 5},
+	}
+	for _, tt := range tests {
+		got, page, size := Paginate(items, tt.page, tt.size)
+		if len(got) != len(tt.want) || page != tt.wantPage || size != tt.wantSize {
+			t.Errorf("Paginate(%d, %d): expected %v page %d size %d, got %v page %d size %d",
+				tt.page, tt.size, tt.want, tt.wantPage, tt.wantSize, got, page, size)
+		}
+	}
+}
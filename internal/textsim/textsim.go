@@ -0,0 +1,58 @@
+// Package textsim holds the word tokenizer and similarity measures shared by
+// search result diversification and the offline backends.
+package textsim
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Words splits s into lowercased runs of letters and digits.
+func Words(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// WordSet returns the distinct words of s.
+func WordSet(s string) map[string]bool {
+	set := map[string]bool{}
+	for _, w := range Words(s) {
+		set[w] = true
+	}
+	return set
+}
+
+// Jaccard returns the Jaccard similarity of two word sets, or 0 if both are
+// empty.
+func Jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	inter := 0
+	for w := range a {
+		if b[w] {
+			inter++
+		}
+	}
+	return float64(inter) / float64(len(a)+len(b)-inter)
+}
+
+// Cosine returns the cosine similarity of a and b, or 0 if their lengths
+// differ or either is zero.
+func Cosine(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
@@ -0,0 +1,30 @@
+package textsim
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestWords(t *testing.T) {
+	got := Words("Likes RAMEN, and pho-bo!")
+	want := []string{"likes", "ramen", "and", "pho", "bo"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	if got := Jaccard(WordSet("likes ramen"), WordSet("Likes pho")); got != 1.0/3 {
+		t.Errorf("expected Jaccard 1/3, got %v", got)
+	}
+	if got := Jaccard(WordSet(""), WordSet("")); got != 0 {
+		t.Errorf("expected Jaccard 0 for empty sets, got %v", got)
+	}
+	if got := Cosine([]float32{1, 1}, []float32{2, 2}); math.Abs(got-1) > 1e-9 {
+		t.Errorf("expected cosine 1, got %v", got)
+	}
+	if got := Cosine([]float32{1, 0}, []float32{0}); got != 0 {
+		t.Errorf("expected cosine 0 for mismatched lengths, got %v", got)
+	}
+}
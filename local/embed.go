@@ -0,0 +1,68 @@
+package local
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+
+	mem0 "github.com/alcova-ai/mem0-go"
+	"github.com/alcova-ai/mem0-go/internal/textsim"
+)
+
+// Embedder turns text into a vector for similarity search. It is the same
+// interface used for search result diversification, so one implementation
+// serves both.
+type Embedder = mem0.Embedder
+
+// HashEmbedder is a deterministic embedder that hashes lowercased word
+// tokens into a fixed number of buckets and normalizes the result. Texts
+// sharing words score higher; it has no notion of synonyms.
+type HashEmbedder struct {
+	Dims int
+}
+
+const defaultDims = 256
+
+// NewHashEmbedder returns a HashEmbedder producing vectors of dims
+// dimensions, or 256 if dims is not positive.
+func NewHashEmbedder(dims int) *HashEmbedder {
+	if dims <= 0 {
+		dims = defaultDims
+	}
+	return &HashEmbedder{Dims: dims}
+}
+
+func (e *HashEmbedder) Embed(_ context.Context, text string) ([]float32, error) {
+	dims := e.Dims
+	if dims <= 0 {
+		dims = defaultDims
+	}
+
+	vec := make([]float32, dims)
+	for _, tok := range textsim.Words(text) {
+		h := fnv.New64a()
+		h.Write([]byte(tok))
+		sum := h.Sum64()
+		sign := float32(1)
+		if sum>>63 == 1 {
+			sign = -1
+		}
+		vec[sum%uint64(dims)] += sign
+	}
+	normalize(vec)
+	return vec, nil
+}
+
+func normalize(v []float32) {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	if sum == 0 {
+		return
+	}
+	n := float32(math.Sqrt(sum))
+	for i := range v {
+		v[i] /= n
+	}
+}
@@ -0,0 +1,51 @@
+package local
+
+import (
+	"context"
+
+	mem0 "github.com/alcova-ai/mem0-go"
+	"github.com/alcova-ai/mem0-go/internal/memstore"
+)
+
+// ListEntities derives the users, agents, apps and runs that own at least
+// one stored memory, sorted by type and ID.
+func (s *Store) ListEntities(ctx context.Context, req *mem0.ListEntitiesRequest) (*mem0.ListEntitiesResponse, error) {
+	var typ mem0.EntityType
+	var page, size int
+	if req != nil {
+		typ, page, size = req.Type, req.Page, req.PageSize
+	}
+
+	s.mu.Lock()
+	entities := s.mem.Entities(typ)
+	s.mu.Unlock()
+
+	results, page, size := memstore.Paginate(entities, page, size)
+	return &mem0.ListEntitiesResponse{
+		Results:  results,
+		Page:     page,
+		PageSize: size,
+		Total:    len(entities),
+	}, nil
+}
+
+// DeleteEntity deletes every memory owned by the entity.
+func (s *Store) DeleteEntity(ctx context.Context, entityType mem0.EntityType, entityID string) error {
+	if entityID == "" {
+		return mem0.ErrMissingID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.change(func() error {
+		ids := s.mem.EntityMemories(entityType, entityID)
+		if len(ids) == 0 {
+			return notFound("Entity not found")
+		}
+		for _, id := range ids {
+			s.mem.Remove(id)
+		}
+		return nil
+	})
+}
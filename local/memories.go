@@ -0,0 +1,366 @@
+package local
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	mem0 "github.com/alcova-ai/mem0-go"
+	"github.com/alcova-ai/mem0-go/internal/memstore"
+	"github.com/alcova-ai/mem0-go/internal/textsim"
+)
+
+const defaultTopK = 10
+
+func (s *Store) AddMemories(ctx context.Context, req *mem0.AddMemoriesRequest) (*mem0.AddMemoriesResponse, error) {
+	if req == nil || len(req.Messages) == 0 {
+		return nil, mem0.ErrEmptyRequest
+	}
+	if req.UserID == "" && req.AgentID == "" && req.AppID == "" && req.RunID == "" {
+		return nil, &mem0.APIError{
+			StatusCode: http.StatusBadRequest,
+			Detail:     "One of user_id, agent_id, app_id or run_id is required",
+		}
+	}
+
+	infer := req.Infer == nil || *req.Infer
+
+	// Embed outside the lock so a slow embedder doesn't block readers.
+	var texts []string
+	var vecs [][]float32
+	for _, msg := range req.Messages {
+		text := strings.TrimSpace(msg.Content)
+		if text == "" || infer && msg.Role != "user" {
+			continue
+		}
+		v, err := s.embed(ctx, text)
+		if err != nil {
+			return nil, err
+		}
+		texts = append(texts, text)
+		vecs = append(vecs, v)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := &mem0.AddMemoriesResponse{}
+	err := s.change(func() error {
+		for i, text := range texts {
+			if s.mem.Duplicate(req, text) {
+				continue
+			}
+			r := s.mem.Insert(mem0.Memory{
+				Memory:         text,
+				UserID:         req.UserID,
+				AgentID:        req.AgentID,
+				AppID:          req.AppID,
+				RunID:          req.RunID,
+				Metadata:       req.Metadata,
+				Immutable:      req.Immutable,
+				ExpirationDate: req.ExpirationDate,
+			}, vecs[i])
+			resp.Results = append(resp.Results, mem0.AddEvent{ID: r.ID, Event: string(mem0.EventAdd), Memory: r.Memory.Memory})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Search ranks the memories matching req.Filters by cosine similarity to
+// the query. Memories with no similarity or scoring below req.Threshold
+// are omitted.
+func (s *Store) Search(ctx context.Context, req *mem0.SearchRequest) (*mem0.SearchResponse, error) {
+	if req == nil || req.Query == "" {
+		return nil, mem0.ErrMissingQuery
+	}
+	if req.Filters == nil {
+		return nil, mem0.ErrMissingFilters
+	}
+	if err := req.Filters.Validate(); err != nil {
+		return nil, err
+	}
+
+	query, err := s.embed(ctx, req.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	matched, err := s.mem.Match(req.Filters)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("local: %w", err)
+	}
+
+	results := make([]mem0.Memory, 0, len(matched))
+	for _, r := range matched {
+		vec := r.Embedding
+		if vec == nil {
+			// Records loaded from a file written without embeddings are
+			// embedded on first use.
+			if vec, err = s.embed(ctx, r.Memory.Memory); err != nil {
+				return nil, err
+			}
+		}
+		m := r.Memory
+		m.Score = textsim.Cosine(query, vec)
+		if m.Score <= 0 || m.Score < req.Threshold {
+			continue
+		}
+		results = append(results, m)
+	}
+	slices.SortStableFunc(results, func(a, b mem0.Memory) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+
+	topK := req.TopK
+	if topK <= 0 {
+		topK = defaultTopK
+	}
+	if req.MMR != nil {
+		// Diversify the same candidate pool the client would fetch.
+		pool := req.MMR.FetchK
+		if pool <= topK {
+			pool = 2 * topK
+		}
+		results, err = mem0.Diversify(ctx, results[:min(pool, len(results))], topK, *req.MMR)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(results) > topK {
+		results = results[:topK]
+	}
+	return &mem0.SearchResponse{Results: results}, nil
+}
+
+func (s *Store) GetMemory(ctx context.Context, memoryID string) (*mem0.Memory, error) {
+	if memoryID == "" {
+		return nil, mem0.ErrMissingID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.mem.Get(memoryID)
+	if !ok {
+		return nil, notFound("Memory not found")
+	}
+	m := memstore.CloneMemory(&r.Memory)
+	return &m, nil
+}
+
+// GetMemories returns the memories matching req.Filters in insertion
+// order, one page at a time if req.Page or req.PageSize is set.
+func (s *Store) GetMemories(ctx context.Context, req *mem0.GetMemoriesRequest) (*mem0.GetMemoriesResponse, error) {
+	if req == nil || req.Filters == nil {
+		return nil, mem0.ErrMissingFilters
+	}
+	if err := req.Filters.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	matched, err := s.mem.Match(req.Filters)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("local: %w", err)
+	}
+
+	memories := make([]mem0.Memory, len(matched))
+	for i, r := range matched {
+		memories[i] = r.Memory
+	}
+	results, page, size := memstore.Paginate(memories, req.Page, req.PageSize)
+	return &mem0.GetMemoriesResponse{
+		Results:  results,
+		Page:     page,
+		PageSize: size,
+		Total:    len(memories),
+	}, nil
+}
+
+func (s *Store) UpdateMemory(ctx context.Context, memoryID string, req *mem0.UpdateMemoryRequest) (*mem0.Memory, error) {
+	if memoryID == "" {
+		return nil, mem0.ErrMissingID
+	}
+	if req == nil {
+		return nil, mem0.ErrEmptyRequest
+	}
+
+	var vec []float32
+	if req.Text != "" {
+		var err error
+		if vec, err = s.embed(ctx, req.Text); err != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var m mem0.Memory
+	err := s.change(func() error {
+		r, ok := s.mem.Get(memoryID)
+		if !ok {
+			return notFound("Memory not found")
+		}
+		if !s.mem.Update(r, req.Text, req.Metadata, vec) {
+			return immutable()
+		}
+		m = memstore.CloneMemory(&r.Memory)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (s *Store) DeleteMemory(ctx context.Context, memoryID string) error {
+	if memoryID == "" {
+		return mem0.ErrMissingID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.change(func() error {
+		if !s.mem.Remove(memoryID) {
+			return notFound("Memory not found")
+		}
+		return nil
+	})
+}
+
+func (s *Store) DeleteMemories(ctx context.Context, req *mem0.DeleteMemoriesRequest) error {
+	if req == nil || req.Filters == nil {
+		return mem0.ErrMissingFilters
+	}
+	if err := req.Filters.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.change(func() error {
+		matched, err := s.mem.Match(req.Filters)
+		if err != nil {
+			return fmt.Errorf("local: %w", err)
+		}
+		for _, r := range matched {
+			s.mem.Remove(r.ID)
+		}
+		return nil
+	})
+}
+
+// GetMemoryHistory returns every recorded change to a memory, including
+// memories that have since been deleted.
+func (s *Store) GetMemoryHistory(ctx context.Context, memoryID string) ([]mem0.MemoryHistory, error) {
+	if memoryID == "" {
+		return nil, mem0.ErrMissingID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	history, ok := s.mem.History(memoryID)
+	if !ok {
+		return nil, notFound("Memory not found")
+	}
+	return history, nil
+}
+
+// BatchUpdate applies every update or none: if any memory is missing no
+// change is made and every ID is reported as failed.
+func (s *Store) BatchUpdate(ctx context.Context, req *mem0.BatchUpdateRequest) (*mem0.BatchResult, error) {
+	if req == nil || len(req.Memories) == 0 {
+		return nil, mem0.ErrEmptyRequest
+	}
+	ids := make([]string, len(req.Memories))
+	for i, item := range req.Memories {
+		ids[i] = item.MemoryID
+	}
+	return batchResult(ids, s.batchUpdate(ctx, req))
+}
+
+func (s *Store) batchUpdate(ctx context.Context, req *mem0.BatchUpdateRequest) error {
+	vecs := make([][]float32, len(req.Memories))
+	for i, item := range req.Memories {
+		if item.Text == "" {
+			continue
+		}
+		var err error
+		if vecs[i], err = s.embed(ctx, item.Text); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.change(func() error {
+		for _, item := range req.Memories {
+			r, ok := s.mem.Get(item.MemoryID)
+			if !ok {
+				return notFound("Memory not found: " + item.MemoryID)
+			}
+			if r.Immutable {
+				return immutable()
+			}
+		}
+		for i, item := range req.Memories {
+			r, _ := s.mem.Get(item.MemoryID)
+			s.mem.Update(r, item.Text, item.Metadata, vecs[i])
+		}
+		return nil
+	})
+}
+
+// BatchDelete deletes every listed memory or none: if any memory is missing
+// no change is made and every ID is reported as failed.
+func (s *Store) BatchDelete(ctx context.Context, req *mem0.BatchDeleteRequest) (*mem0.BatchResult, error) {
+	if req == nil || len(req.MemoryIDs) == 0 {
+		return nil, mem0.ErrEmptyRequest
+	}
+	return batchResult(req.MemoryIDs, s.batchDelete(req.MemoryIDs))
+}
+
+func (s *Store) batchDelete(ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.change(func() error {
+		for _, id := range ids {
+			if _, ok := s.mem.Get(id); !ok {
+				return notFound("Memory not found: " + id)
+			}
+		}
+		for _, id := range ids {
+			s.mem.Remove(id)
+		}
+		return nil
+	})
+}
+
+// batchResult reports every ID as succeeded, or as failed with err.
+func batchResult(ids []string, err error) (*mem0.BatchResult, error) {
+	res := &mem0.BatchResult{}
+	if err == nil {
+		res.Succeeded = slices.Clone(ids)
+		return res, nil
+	}
+	for _, id := range ids {
+		res.Failed = append(res.Failed, mem0.BatchFailure{ID: id, Err: err})
+	}
+	return res, res.Err()
+}
@@ -0,0 +1,143 @@
+// Package local is an embedded, offline implementation of mem0.MemoryStore.
+//
+// Memories are kept in memory and, when a path is given, persisted to a
+// single JSON file after every change. Filters are evaluated with the
+// platform's v2 semantics, every change is recorded in the memory's
+// history, and search ranks memories by cosine similarity using a
+// pluggable Embedder:
+//
+//	store, err := local.Open("memories.json")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	var s mem0.MemoryStore = store
+//
+// Without an LLM to extract facts, adds with inference enabled store each
+// user message verbatim; with inference disabled every message is stored.
+package local
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	mem0 "github.com/alcova-ai/mem0-go"
+	"github.com/alcova-ai/mem0-go/internal/fsutil"
+	"github.com/alcova-ai/mem0-go/internal/memstore"
+)
+
+var _ mem0.MemoryStore = (*Store)(nil)
+
+// Store is a file-backed memory store. It is safe for concurrent use.
+type Store struct {
+	path     string
+	embedder Embedder
+	now      func() time.Time
+
+	mu  sync.Mutex
+	mem *memstore.Store
+}
+
+// Option configures a Store.
+type Option func(*Store)
+
+// WithEmbedder sets the embedder used for search. The default is a
+// HashEmbedder with 256 dimensions.
+func WithEmbedder(e Embedder) Option {
+	return func(s *Store) { s.embedder = e }
+}
+
+// WithClock sets the function used to timestamp memories and history.
+func WithClock(now func() time.Time) Option {
+	return func(s *Store) { s.now = now }
+}
+
+// Open loads the store at path, creating it on the first write if it does
+// not exist. An empty path keeps everything in memory.
+func Open(path string, opts ...Option) (*Store, error) {
+	s := &Store{
+		path:     path,
+		embedder: NewHashEmbedder(defaultDims),
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.mem = memstore.New(s.now)
+
+	if path == "" {
+		return s, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("local: open %s: %w", path, err)
+	}
+
+	var st memstore.State
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("local: decode %s: %w", path, err)
+	}
+	s.mem = memstore.FromState(st, s.now)
+	return s, nil
+}
+
+// change applies fn to the store and saves it. If fn or the save fails the
+// store is restored to its state before fn, so it never holds changes that
+// are not on disk. Callers hold s.mu.
+func (s *Store) change(fn func() error) error {
+	var prev *memstore.Store
+	if s.path != "" {
+		prev = s.mem.Clone()
+	}
+	err := fn()
+	if err == nil {
+		err = s.save()
+	}
+	if err != nil && prev != nil {
+		s.mem = prev
+	}
+	return err
+}
+
+// save writes the store to disk, replacing the previous file atomically
+// and durably.
+// Callers hold s.mu.
+func (s *Store) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(s.mem.State())
+	if err != nil {
+		return fmt.Errorf("local: encode: %w", err)
+	}
+
+	if err := fsutil.WriteAtomic(s.path, data); err != nil {
+		return fmt.Errorf("local: save: %w", err)
+	}
+	return nil
+}
+
+func notFound(detail string) error {
+	return &mem0.APIError{StatusCode: http.StatusNotFound, Detail: detail}
+}
+
+func immutable() error {
+	return &mem0.APIError{StatusCode: http.StatusBadRequest, Detail: "Immutable memories cannot be updated"}
+}
+
+func (s *Store) embed(ctx context.Context, text string) ([]float32, error) {
+	v, err := s.embedder.Embed(ctx, text)
+	if err != nil {
+		return nil, fmt.Errorf("local: embed: %w", err)
+	}
+	return v, nil
+}
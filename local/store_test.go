@@ -0,0 +1,193 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mem0 "github.com/alcova-ai/mem0-go"
+	"github.com/alcova-ai/mem0-go/internal/textsim"
+)
+
+func TestStorePersistsAcrossOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "memories.json")
+	ctx := context.Background()
+
+	store, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: unexpected error: %v", err)
+	}
+	add, err := store.AddMemories(ctx, &mem0.AddMemoriesRequest{
+		Messages: []mem0.Message{{Role: "user", Content: "Likes spicy ramen"}},
+		UserID:   "u1",
+	})
+	if err != nil {
+		t.Fatalf("AddMemories: unexpected error: %v", err)
+	}
+	id := add.Results[0].ID
+	if _, err := store.UpdateMemory(ctx, id, &mem0.UpdateMemoryRequest{Text: "Likes mild ramen"}); err != nil {
+		t.Fatalf("UpdateMemory: unexpected error: %v", err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: unexpected error: %v", err)
+	}
+	mem, err := reopened.GetMemory(ctx, id)
+	if err != nil {
+		t.Fatalf("GetMemory: unexpected error: %v", err)
+	}
+	if mem.Memory != "Likes mild ramen" || mem.UserID != "u1" {
+		t.Errorf("expected persisted update, got %+v", mem)
+	}
+
+	if err := reopened.DeleteMemory(ctx, id); err != nil {
+		t.Fatalf("DeleteMemory: unexpected error: %v", err)
+	}
+	_, err = reopened.GetMemory(ctx, id)
+	var apiErr *mem0.APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("expected not found after delete, got %v", err)
+	}
+
+	history, err := reopened.GetMemoryHistory(ctx, id)
+	if err != nil {
+		t.Fatalf("GetMemoryHistory: unexpected error: %v", err)
+	}
+	if len(history) != 3 || history[0].Event != "ADD" || history[1].Event != "UPDATE" || history[2].Event != "DELETE" {
+		t.Errorf("expected ADD, UPDATE, DELETE history, got %+v", history)
+	}
+}
+
+func TestStoreRollsBackFailedSave(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "data")
+	os.Mkdir(dir, 0o700)
+	ctx := context.Background()
+
+	store, _ := Open(filepath.Join(dir, "memories.json"))
+	add, err := store.AddMemories(ctx, &mem0.AddMemoriesRequest{
+		Messages: []mem0.Message{{Role: "user", Content: "Likes ramen"}},
+		UserID:   "u1",
+	})
+	if err != nil {
+		t.Fatalf("AddMemories: unexpected error: %v", err)
+	}
+	id := add.Results[0].ID
+
+	// Saves fail once the directory is gone.
+	os.RemoveAll(dir)
+	if _, err := store.AddMemories(ctx, &mem0.AddMemoriesRequest{
+		Messages: []mem0.Message{{Role: "user", Content: "Lives in Lisbon"}},
+		UserID:   "u1",
+	}); err == nil {
+		t.Error("AddMemories: expected save error")
+	}
+	if _, err := store.UpdateMemory(ctx, id, &mem0.UpdateMemoryRequest{Text: "Likes pho"}); err == nil {
+		t.Error("UpdateMemory: expected save error")
+	}
+	if err := store.DeleteMemory(ctx, id); err == nil {
+		t.Error("DeleteMemory: expected save error")
+	}
+
+	resp, _ := store.GetMemories(ctx, &mem0.GetMemoriesRequest{Filters: mem0.NewFilters().WithUserID("u1")})
+	if len(resp.Results) != 1 || resp.Results[0].Memory != "Likes ramen" {
+		t.Errorf("expected failed changes to be rolled back, got %+v", resp.Results)
+	}
+	if history, _ := store.GetMemoryHistory(ctx, id); len(history) != 1 {
+		t.Errorf("expected only the ADD in history, got %+v", history)
+	}
+}
+
+func TestStoreSearch(t *testing.T) {
+	store, _ := Open("")
+	ctx := context.Background()
+
+	for _, text := range []string{"Enjoys hiking in the alps", "Allergic to peanuts", "Prefers window seats"} {
+		store.AddMemories(ctx, &mem0.AddMemoriesRequest{
+			Messages: []mem0.Message{{Role: "user", Content: text}},
+			UserID:   "u1",
+		})
+	}
+	store.AddMemories(ctx, &mem0.AddMemoriesRequest{
+		Messages: []mem0.Message{{Role: "user", Content: "Enjoys hiking too"}},
+		UserID:   "u2",
+	})
+
+	resp, err := store.Search(ctx, &mem0.SearchRequest{
+		Query:   "hiking trips",
+		Filters: mem0.NewFilters().WithUserID("u1"),
+	})
+	if err != nil {
+		t.Fatalf("Search: unexpected error: %v", err)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].Memory != "Enjoys hiking in the alps" {
+		t.Fatalf("expected only the hiking memory for u1, got %+v", resp.Results)
+	}
+	if resp.Results[0].Score <= 0 || resp.Results[0].Score > 1 {
+		t.Errorf("expected score in (0, 1], got %v", resp.Results[0].Score)
+	}
+}
+
+func TestStoreFilters(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := base
+	store, _ := Open("", WithClock(func() time.Time { return now }))
+	ctx := context.Background()
+
+	add := func(user, text string) {
+		store.AddMemories(ctx, &mem0.AddMemoriesRequest{
+			Messages: []mem0.Message{{Role: "user", Content: text}},
+			UserID:   user,
+			Metadata: map[string]any{"source": "chat"},
+		})
+		now = now.AddDate(0, 1, 0)
+	}
+	add("u1", "first")
+	add("u1", "second")
+	add("u2", "third")
+
+	tests := []struct {
+		name    string
+		filters mem0.Filters
+		want    int
+	}{
+		{"user", mem0.NewFilters().WithUserID("u1"), 2},
+		{"created after", mem0.NewFilters().WithUserID("u1").WithCreatedAfter(base.AddDate(0, 0, 15)), 1},
+		{"or", mem0.NewFilters().WithUserID("u2").Or(mem0.NewFilters().WithCreatedBefore(base)), 2},
+		{"in", mem0.Filters{"user_id": map[string]any{"in": []string{"u1", "u2"}}}, 3},
+		{"metadata", mem0.Filters{"metadata": map[string]any{"source": "chat"}}, 3},
+	}
+	for _, tt := range tests {
+		resp, err := store.GetMemories(ctx, &mem0.GetMemoriesRequest{Filters: tt.filters})
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if len(resp.Results) != tt.want {
+			t.Errorf("%s: expected %d results, got %d", tt.name, tt.want, len(resp.Results))
+		}
+	}
+
+	entities, err := store.ListEntities(ctx, &mem0.ListEntitiesRequest{Type: mem0.EntityTypeUser})
+	if err != nil {
+		t.Fatalf("ListEntities: unexpected error: %v", err)
+	}
+	if len(entities.Results) != 2 || entities.Results[0].TotalMemories != 2 {
+		t.Errorf("expected u1 with 2 memories and u2, got %+v", entities.Results)
+	}
+}
+
+func TestHashEmbedderDeterministic(t *testing.T) {
+	e := NewHashEmbedder(64)
+	a, _ := e.Embed(context.Background(), "Likes Ramen")
+	b, _ := e.Embed(context.Background(), "likes ramen!")
+	if len(a) != 64 {
+		t.Fatalf("expected 64 dimensions, got %d", len(a))
+	}
+	if got := textsim.Cosine(a, b); got < 0.999 {
+		t.Errorf("expected identical embeddings, got similarity %v", got)
+	}
+}
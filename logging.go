@@ -0,0 +1,127 @@
+package mem0
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are JSON fields holding user content. Their values, of any
+// type, are replaced in logged bodies unless body logging is enabled.
+var sensitiveKeys = map[string]bool{
+	"content":    true,
+	"memory":     true,
+	"text":       true,
+	"query":      true,
+	"old_memory": true,
+	"new_memory": true,
+}
+
+// logAttempt logs the outcome of one HTTP attempt. Successful attempts are
+// logged at the configured level and failed ones at Warn. Request and
+// response bodies are only included at Debug.
+func (c *Client) logAttempt(ctx context.Context, n int, call *Call, latency time.Duration, err error) {
+	level := c.logLevel
+	if err != nil && level < slog.LevelWarn {
+		level = slog.LevelWarn
+	}
+	if !c.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("operation", call.Operation),
+		slog.String("method", call.HTTPRequest.Method),
+		slog.String("path", call.HTTPRequest.URL.Path),
+		slog.Duration("latency", latency),
+		slog.Int("attempt", n+1),
+	}
+	if resp := call.HTTPResponse; resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		if id := resp.Header.Get("X-Request-Id"); id != "" {
+			attrs = append(attrs, slog.String("request_id", id))
+		}
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	if c.logger.Enabled(ctx, slog.LevelDebug) {
+		attrs = append(attrs, slog.Any("headers", redactHeaders(call.HTTPRequest.Header)))
+		if call.Request != nil {
+			if data, mErr := json.Marshal(call.Request); mErr == nil {
+				attrs = append(attrs, slog.String("request_body", c.logBody(data)))
+			}
+		}
+		if len(call.ResponseBody) > 0 {
+			attrs = append(attrs, slog.String("response_body", c.logBody(call.ResponseBody)))
+		}
+	}
+
+	msg := "mem0 request"
+	if err != nil {
+		msg = "mem0 request failed"
+	}
+	c.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+func (c *Client) logRetry(ctx context.Context, op string, n int, wait time.Duration, err error) {
+	c.logger.LogAttrs(ctx, slog.LevelInfo, "mem0 retrying request",
+		slog.String("operation", op),
+		slog.Int("attempt", n+2),
+		slog.Duration("backoff", wait),
+		slog.String("error", err.Error()),
+	)
+}
+
+func (c *Client) logBody(data []byte) string {
+	if c.logBodies {
+		return string(data)
+	}
+
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return redacted
+	}
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return redacted
+	}
+	return string(out)
+}
+
+func redactValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			if sensitiveKeys[k] {
+				t[k] = redacted
+				continue
+			}
+			t[k] = redactValue(val)
+		}
+	case []any:
+		for i, val := range t {
+			t[i] = redactValue(val)
+		}
+	}
+	return v
+}
+
+func redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		if k == "Authorization" {
+			out[k] = redacted
+			continue
+		}
+		if len(v) > 0 {
+			out[k] = v[0]
+		}
+	}
+	return out
+}
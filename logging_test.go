@@ -0,0 +1,111 @@
+package mem0
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newLoggingServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-42")
+		json.NewEncoder(w).Encode(AddMemoriesResponse{
+			Results: []AddEvent{{ID: "mem-1", Event: "ADD", Memory: "secret preference"}},
+		})
+	}))
+}
+
+func TestLoggingRedactsByDefault(t *testing.T) {
+	server := newLoggingServer()
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client, _ := NewClient("super-secret-key", WithBaseURL(server.URL), WithLogger(logger))
+
+	_, err := client.AddMemory(context.Background(), "my secret content", WithUserID("user-1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	for _, leak := range []string{"super-secret-key", "my secret content", "secret preference"} {
+		if strings.Contains(out, leak) {
+			t.Errorf("log output leaked %q: %s", leak, out)
+		}
+	}
+	for _, want := range []string{`"status":200`, `"request_id":"req-42"`, `"operation":"AddMemories"`, `user-1`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected log output to contain %s: %s", want, out)
+		}
+	}
+}
+
+func TestLoggingRedactsNonStringValues(t *testing.T) {
+	client, _ := NewClient("test-key")
+	body := `{"content":{"parts":["secret part"]},"text":["secret line"],"memory":42,"user_id":"user-1"}`
+
+	out := client.logBody([]byte(body))
+	for _, leak := range []string{"secret part", "secret line", "42"} {
+		if strings.Contains(out, leak) {
+			t.Errorf("log output leaked %q: %s", leak, out)
+		}
+	}
+	if !strings.Contains(out, "user-1") {
+		t.Errorf("expected log output to contain user-1: %s", out)
+	}
+}
+
+func TestLoggingBodies(t *testing.T) {
+	server := newLoggingServer()
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client, _ := NewClient("super-secret-key",
+		WithBaseURL(server.URL),
+		WithLogger(logger),
+		WithLogBodies(true),
+	)
+
+	_, err := client.AddMemory(context.Background(), "my secret content", WithUserID("user-1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "my secret content") {
+		t.Errorf("expected request body in log output: %s", out)
+	}
+	if strings.Contains(out, "super-secret-key") {
+		t.Errorf("log output leaked API key: %s", out)
+	}
+}
+
+func TestLoggingLevel(t *testing.T) {
+	server := newLoggingServer()
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	client, _ := NewClient("test-key", WithBaseURL(server.URL), WithLogger(logger))
+
+	client.AddMemory(context.Background(), "content", WithUserID("user-1"))
+	if buf.Len() != 0 {
+		t.Errorf("expected no output below the configured level, got %s", buf.String())
+	}
+
+	client, _ = NewClient("test-key", WithBaseURL(server.URL), WithLogger(logger), WithLogLevel(slog.LevelInfo))
+	client.AddMemory(context.Background(), "content", WithUserID("user-1"))
+	if !strings.Contains(buf.String(), `"msg":"mem0 request"`) {
+		t.Errorf("expected request to be logged at Info, got %s", buf.String())
+	}
+	if strings.Contains(buf.String(), "request_body") {
+		t.Errorf("expected bodies to be omitted above Debug, got %s", buf.String())
+	}
+}
@@ -0,0 +1,67 @@
+package mem0
+
+import (
+	"fmt"
+
+	"github.com/alcova-ai/mem0-go/internal/filtereval"
+)
+
+// Match reports whether m satisfies the filters, using the same semantics
+// as the platform: AND, OR and NOT nesting, the * wildcard, metadata keys
+// and the eq, ne, in, nin, contains, icontains, gt, gte, lt and lte
+// operators. Dates compare chronologically whether given as RFC3339 or
+// YYYY-MM-DD. It returns an error for malformed filters.
+//
+// Match lets a filter sent to the API also be applied to memories already
+// in hand, for example after a cache hit:
+//
+//	for _, m := range cached {
+//	    if ok, _ := filters.Match(m); ok {
+//	        out = append(out, m)
+//	    }
+//	}
+func (f Filters) Match(m Memory) (bool, error) {
+	ok, err := filtereval.Match(f, memoryField(&m))
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+	}
+	return ok, nil
+}
+
+// memoryField exposes a memory's fields to the filter evaluator under
+// their JSON names. Empty scalar fields are reported as absent so that
+// wildcards only match set values.
+func memoryField(m *Memory) filtereval.Getter {
+	return func(field string) (any, bool) {
+		var v string
+		switch field {
+		case "id", "memory_id":
+			v = m.ID
+		case "memory":
+			v = m.Memory
+		case "user_id":
+			v = m.UserID
+		case "agent_id":
+			v = m.AgentID
+		case "app_id":
+			v = m.AppID
+		case "run_id":
+			v = m.RunID
+		case "hash":
+			v = m.Hash
+		case "categories":
+			return m.Categories, len(m.Categories) > 0
+		case "metadata":
+			return m.Metadata, m.Metadata != nil
+		case "immutable":
+			return m.Immutable, true
+		case "created_at":
+			return m.CreatedAt, !m.CreatedAt.IsZero()
+		case "updated_at":
+			return m.UpdatedAt, !m.UpdatedAt.IsZero()
+		default:
+			return nil, false
+		}
+		return v, v != ""
+	}
+}
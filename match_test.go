@@ -0,0 +1,55 @@
+package mem0
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFiltersMatch(t *testing.T) {
+	base := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	m := Memory{
+		ID:         "mem-1",
+		Memory:     "Enjoys hiking in the alps",
+		UserID:     "u1",
+		Categories: []string{"travel", "hobbies"},
+		Metadata:   map[string]any{"tier": "gold", "score": 7},
+		CreatedAt:  base,
+	}
+
+	tests := []struct {
+		name    string
+		filters Filters
+		want    bool
+	}{
+		{"user", NewFilters().WithUserID("u1"), true},
+		{"other user", NewFilters().WithUserID("u2"), false},
+		{"unset agent", NewFilters().WithAgentID("a1"), false},
+		{"categories in", NewFilters().WithCategories("food", "travel"), true},
+		{"categories contains", NewFilters().WithCategoryContains("food"), false},
+		{"created range", NewFilters().WithCreatedAfter(base.AddDate(0, 0, -1)).WithCreatedBefore(base), true},
+		{"created after", NewFilters().WithCreatedAfter(base.AddDate(0, 0, 1)), false},
+		{"or", NewFilters().WithUserID("u2").Or(NewFilters().WithCategories("hobbies")), true},
+		{"or then and", NewFilters().WithUserID("u2").Or(NewFilters().WithUserID("u1")).WithAgentID("a1"), false},
+		{"metadata", Metadata("tier").Eq("gold").Filters(), true},
+		{"metadata range", And(Metadata("score").Gte(5), Metadata("score").Lt(10)).Filters(), true},
+		{"not", Not(IContains("memory", "HIKING")).Filters(), false},
+		{"wildcard", Any("user_id").Filters(), true},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.filters.Match(m)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+
+	_, err := Filters{"user_id": map[string]any{"between": 1}}.Match(m)
+	if !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("expected ErrInvalidFilter, got %v", err)
+	}
+}
@@ -0,0 +1,644 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mem0mock
+
+import (
+	"context"
+	"github.com/alcova-ai/mem0-go"
+	"sync"
+)
+
+// Ensure, that MemoryStoreMock does implement mem0.MemoryStore.
+// If this is not the case, regenerate this file with moq.
+var _ mem0.MemoryStore = &MemoryStoreMock{}
+
+// MemoryStoreMock is a mock implementation of mem0.MemoryStore.
+//
+//	func TestSomethingThatUsesMemoryStore(t *testing.T) {
+//
+//		// make and configure a mocked mem0.MemoryStore
+//		mockedMemoryStore := &MemoryStoreMock{
+//			AddMemoriesFunc: func(ctx context.Context, req *mem0.AddMemoriesRequest) (*mem0.AddMemoriesResponse, error) {
+//				panic("mock out the AddMemories method")
+//			},
+//			BatchDeleteFunc: func(ctx context.Context, req *mem0.BatchDeleteRequest) (*mem0.BatchResult, error) {
+//				panic("mock out the BatchDelete method")
+//			},
+//			BatchUpdateFunc: func(ctx context.Context, req *mem0.BatchUpdateRequest) (*mem0.BatchResult, error) {
+//				panic("mock out the BatchUpdate method")
+//			},
+//			DeleteEntityFunc: func(ctx context.Context, entityType mem0.EntityType, entityID string) error {
+//				panic("mock out the DeleteEntity method")
+//			},
+//			DeleteMemoriesFunc: func(ctx context.Context, req *mem0.DeleteMemoriesRequest) error {
+//				panic("mock out the DeleteMemories method")
+//			},
+//			DeleteMemoryFunc: func(ctx context.Context, memoryID string) error {
+//				panic("mock out the DeleteMemory method")
+//			},
+//			GetMemoriesFunc: func(ctx context.Context, req *mem0.GetMemoriesRequest) (*mem0.GetMemoriesResponse, error) {
+//				panic("mock out the GetMemories method")
+//			},
+//			GetMemoryFunc: func(ctx context.Context, memoryID string) (*mem0.Memory, error) {
+//				panic("mock out the GetMemory method")
+//			},
+//			GetMemoryHistoryFunc: func(ctx context.Context, memoryID string) ([]mem0.MemoryHistory, error) {
+//				panic("mock out the GetMemoryHistory method")
+//			},
+//			ListEntitiesFunc: func(ctx context.Context, req *mem0.ListEntitiesRequest) (*mem0.ListEntitiesResponse, error) {
+//				panic("mock out the ListEntities method")
+//			},
+//			SearchFunc: func(ctx context.Context, req *mem0.SearchRequest) (*mem0.SearchResponse, error) {
+//				panic("mock out the Search method")
+//			},
+//			UpdateMemoryFunc: func(ctx context.Context, memoryID string, req *mem0.UpdateMemoryRequest) (*mem0.Memory, error) {
+//				panic("mock out the UpdateMemory method")
+//			},
+//		}
+//
+//		// use mockedMemoryStore in code that requires mem0.MemoryStore
+//		// and then make assertions.
+//
+//	}
+type MemoryStoreMock struct {
+	// AddMemoriesFunc mocks the AddMemories method.
+	AddMemoriesFunc func(ctx context.Context, req *mem0.AddMemoriesRequest) (*mem0.AddMemoriesResponse, error)
+
+	// BatchDeleteFunc mocks the BatchDelete method.
+	BatchDeleteFunc func(ctx context.Context, req *mem0.BatchDeleteRequest) (*mem0.BatchResult, error)
+
+	// BatchUpdateFunc mocks the BatchUpdate method.
+	BatchUpdateFunc func(ctx context.Context, req *mem0.BatchUpdateRequest) (*mem0.BatchResult, error)
+
+	// DeleteEntityFunc mocks the DeleteEntity method.
+	DeleteEntityFunc func(ctx context.Context, entityType mem0.EntityType, entityID string) error
+
+	// DeleteMemoriesFunc mocks the DeleteMemories method.
+	DeleteMemoriesFunc func(ctx context.Context, req *mem0.DeleteMemoriesRequest) error
+
+	// DeleteMemoryFunc mocks the DeleteMemory method.
+	DeleteMemoryFunc func(ctx context.Context, memoryID string) error
+
+	// GetMemoriesFunc mocks the GetMemories method.
+	GetMemoriesFunc func(ctx context.Context, req *mem0.GetMemoriesRequest) (*mem0.GetMemoriesResponse, error)
+
+	// GetMemoryFunc mocks the GetMemory method.
+	GetMemoryFunc func(ctx context.Context, memoryID string) (*mem0.Memory, error)
+
+	// GetMemoryHistoryFunc mocks the GetMemoryHistory method.
+	GetMemoryHistoryFunc func(ctx context.Context, memoryID string) ([]mem0.MemoryHistory, error)
+
+	// ListEntitiesFunc mocks the ListEntities method.
+	ListEntitiesFunc func(ctx context.Context, req *mem0.ListEntitiesRequest) (*mem0.ListEntitiesResponse, error)
+
+	// SearchFunc mocks the Search method.
+	SearchFunc func(ctx context.Context, req *mem0.SearchRequest) (*mem0.SearchResponse, error)
+
+	// UpdateMemoryFunc mocks the UpdateMemory method.
+	UpdateMemoryFunc func(ctx context.Context, memoryID string, req *mem0.UpdateMemoryRequest) (*mem0.Memory, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AddMemories holds details about calls to the AddMemories method.
+		AddMemories []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *mem0.AddMemoriesRequest
+		}
+		// BatchDelete holds details about calls to the BatchDelete method.
+		BatchDelete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *mem0.BatchDeleteRequest
+		}
+		// BatchUpdate holds details about calls to the BatchUpdate method.
+		BatchUpdate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *mem0.BatchUpdateRequest
+		}
+		// DeleteEntity holds details about calls to the DeleteEntity method.
+		DeleteEntity []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// EntityType is the entityType argument value.
+			EntityType mem0.EntityType
+			// EntityID is the entityID argument value.
+			EntityID string
+		}
+		// DeleteMemories holds details about calls to the DeleteMemories method.
+		DeleteMemories []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *mem0.DeleteMemoriesRequest
+		}
+		// DeleteMemory holds details about calls to the DeleteMemory method.
+		DeleteMemory []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// MemoryID is the memoryID argument value.
+			MemoryID string
+		}
+		// GetMemories holds details about calls to the GetMemories method.
+		GetMemories []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *mem0.GetMemoriesRequest
+		}
+		// GetMemory holds details about calls to the GetMemory method.
+		GetMemory []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// MemoryID is the memoryID argument value.
+			MemoryID string
+		}
+		// GetMemoryHistory holds details about calls to the GetMemoryHistory method.
+		GetMemoryHistory []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// MemoryID is the memoryID argument value.
+			MemoryID string
+		}
+		// ListEntities holds details about calls to the ListEntities method.
+		ListEntities []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *mem0.ListEntitiesRequest
+		}
+		// Search holds details about calls to the Search method.
+		Search []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req *mem0.SearchRequest
+		}
+		// UpdateMemory holds details about calls to the UpdateMemory method.
+		UpdateMemory []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// MemoryID is the memoryID argument value.
+			MemoryID string
+			// Req is the req argument value.
+			Req *mem0.UpdateMemoryRequest
+		}
+	}
+	lockAddMemories      sync.RWMutex
+	lockBatchDelete      sync.RWMutex
+	lockBatchUpdate      sync.RWMutex
+	lockDeleteEntity     sync.RWMutex
+	lockDeleteMemories   sync.RWMutex
+	lockDeleteMemory     sync.RWMutex
+	lockGetMemories      sync.RWMutex
+	lockGetMemory        sync.RWMutex
+	lockGetMemoryHistory sync.RWMutex
+	lockListEntities     sync.RWMutex
+	lockSearch           sync.RWMutex
+	lockUpdateMemory     sync.RWMutex
+}
+
+// AddMemories calls AddMemoriesFunc.
+func (mock *MemoryStoreMock) AddMemories(ctx context.Context, req *mem0.AddMemoriesRequest) (*mem0.AddMemoriesResponse, error) {
+	if mock.AddMemoriesFunc == nil {
+		panic("MemoryStoreMock.AddMemoriesFunc: method is nil but MemoryStore.AddMemories was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *mem0.AddMemoriesRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockAddMemories.Lock()
+	mock.calls.AddMemories = append(mock.calls.AddMemories, callInfo)
+	mock.lockAddMemories.Unlock()
+	return mock.AddMemoriesFunc(ctx, req)
+}
+
+// AddMemoriesCalls gets all the calls that were made to AddMemories.
+// Check the length with:
+//
+//	len(mockedMemoryStore.AddMemoriesCalls())
+func (mock *MemoryStoreMock) AddMemoriesCalls() []struct {
+	Ctx context.Context
+	Req *mem0.AddMemoriesRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *mem0.AddMemoriesRequest
+	}
+	mock.lockAddMemories.RLock()
+	calls = mock.calls.AddMemories
+	mock.lockAddMemories.RUnlock()
+	return calls
+}
+
+// BatchDelete calls BatchDeleteFunc.
+func (mock *MemoryStoreMock) BatchDelete(ctx context.Context, req *mem0.BatchDeleteRequest) (*mem0.BatchResult, error) {
+	if mock.BatchDeleteFunc == nil {
+		panic("MemoryStoreMock.BatchDeleteFunc: method is nil but MemoryStore.BatchDelete was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *mem0.BatchDeleteRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockBatchDelete.Lock()
+	mock.calls.BatchDelete = append(mock.calls.BatchDelete, callInfo)
+	mock.lockBatchDelete.Unlock()
+	return mock.BatchDeleteFunc(ctx, req)
+}
+
+// BatchDeleteCalls gets all the calls that were made to BatchDelete.
+// Check the length with:
+//
+//	len(mockedMemoryStore.BatchDeleteCalls())
+func (mock *MemoryStoreMock) BatchDeleteCalls() []struct {
+	Ctx context.Context
+	Req *mem0.BatchDeleteRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *mem0.BatchDeleteRequest
+	}
+	mock.lockBatchDelete.RLock()
+	calls = mock.calls.BatchDelete
+	mock.lockBatchDelete.RUnlock()
+	return calls
+}
+
+// BatchUpdate calls BatchUpdateFunc.
+func (mock *MemoryStoreMock) BatchUpdate(ctx context.Context, req *mem0.BatchUpdateRequest) (*mem0.BatchResult, error) {
+	if mock.BatchUpdateFunc == nil {
+		panic("MemoryStoreMock.BatchUpdateFunc: method is nil but MemoryStore.BatchUpdate was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *mem0.BatchUpdateRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockBatchUpdate.Lock()
+	mock.calls.BatchUpdate = append(mock.calls.BatchUpdate, callInfo)
+	mock.lockBatchUpdate.Unlock()
+	return mock.BatchUpdateFunc(ctx, req)
+}
+
+// BatchUpdateCalls gets all the calls that were made to BatchUpdate.
+// Check the length with:
+//
+//	len(mockedMemoryStore.BatchUpdateCalls())
+func (mock *MemoryStoreMock) BatchUpdateCalls() []struct {
+	Ctx context.Context
+	Req *mem0.BatchUpdateRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *mem0.BatchUpdateRequest
+	}
+	mock.lockBatchUpdate.RLock()
+	calls = mock.calls.BatchUpdate
+	mock.lockBatchUpdate.RUnlock()
+	return calls
+}
+
+// DeleteEntity calls DeleteEntityFunc.
+func (mock *MemoryStoreMock) DeleteEntity(ctx context.Context, entityType mem0.EntityType, entityID string) error {
+	if mock.DeleteEntityFunc == nil {
+		panic("MemoryStoreMock.DeleteEntityFunc: method is nil but MemoryStore.DeleteEntity was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		EntityType mem0.EntityType
+		EntityID   string
+	}{
+		Ctx:        ctx,
+		EntityType: entityType,
+		EntityID:   entityID,
+	}
+	mock.lockDeleteEntity.Lock()
+	mock.calls.DeleteEntity = append(mock.calls.DeleteEntity, callInfo)
+	mock.lockDeleteEntity.Unlock()
+	return mock.DeleteEntityFunc(ctx, entityType, entityID)
+}
+
+// DeleteEntityCalls gets all the calls that were made to DeleteEntity.
+// Check the length with:
+//
+//	len(mockedMemoryStore.DeleteEntityCalls())
+func (mock *MemoryStoreMock) DeleteEntityCalls() []struct {
+	Ctx        context.Context
+	EntityType mem0.EntityType
+	EntityID   string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		EntityType mem0.EntityType
+		EntityID   string
+	}
+	mock.lockDeleteEntity.RLock()
+	calls = mock.calls.DeleteEntity
+	mock.lockDeleteEntity.RUnlock()
+	return calls
+}
+
+// DeleteMemories calls DeleteMemoriesFunc.
+func (mock *MemoryStoreMock) DeleteMemories(ctx context.Context, req *mem0.DeleteMemoriesRequest) error {
+	if mock.DeleteMemoriesFunc == nil {
+		panic("MemoryStoreMock.DeleteMemoriesFunc: method is nil but MemoryStore.DeleteMemories was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *mem0.DeleteMemoriesRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockDeleteMemories.Lock()
+	mock.calls.DeleteMemories = append(mock.calls.DeleteMemories, callInfo)
+	mock.lockDeleteMemories.Unlock()
+	return mock.DeleteMemoriesFunc(ctx, req)
+}
+
+// DeleteMemoriesCalls gets all the calls that were made to DeleteMemories.
+// Check the length with:
+//
+//	len(mockedMemoryStore.DeleteMemoriesCalls())
+func (mock *MemoryStoreMock) DeleteMemoriesCalls() []struct {
+	Ctx context.Context
+	Req *mem0.DeleteMemoriesRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *mem0.DeleteMemoriesRequest
+	}
+	mock.lockDeleteMemories.RLock()
+	calls = mock.calls.DeleteMemories
+	mock.lockDeleteMemories.RUnlock()
+	return calls
+}
+
+// DeleteMemory calls DeleteMemoryFunc.
+func (mock *MemoryStoreMock) DeleteMemory(ctx context.Context, memoryID string) error {
+	if mock.DeleteMemoryFunc == nil {
+		panic("MemoryStoreMock.DeleteMemoryFunc: method is nil but MemoryStore.DeleteMemory was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		MemoryID string
+	}{
+		Ctx:      ctx,
+		MemoryID: memoryID,
+	}
+	mock.lockDeleteMemory.Lock()
+	mock.calls.DeleteMemory = append(mock.calls.DeleteMemory, callInfo)
+	mock.lockDeleteMemory.Unlock()
+	return mock.DeleteMemoryFunc(ctx, memoryID)
+}
+
+// DeleteMemoryCalls gets all the calls that were made to DeleteMemory.
+// Check the length with:
+//
+//	len(mockedMemoryStore.DeleteMemoryCalls())
+func (mock *MemoryStoreMock) DeleteMemoryCalls() []struct {
+	Ctx      context.Context
+	MemoryID string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		MemoryID string
+	}
+	mock.lockDeleteMemory.RLock()
+	calls = mock.calls.DeleteMemory
+	mock.lockDeleteMemory.RUnlock()
+	return calls
+}
+
+// GetMemories calls GetMemoriesFunc.
+func (mock *MemoryStoreMock) GetMemories(ctx context.Context, req *mem0.GetMemoriesRequest) (*mem0.GetMemoriesResponse, error) {
+	if mock.GetMemoriesFunc == nil {
+		panic("MemoryStoreMock.GetMemoriesFunc: method is nil but MemoryStore.GetMemories was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *mem0.GetMemoriesRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockGetMemories.Lock()
+	mock.calls.GetMemories = append(mock.calls.GetMemories, callInfo)
+	mock.lockGetMemories.Unlock()
+	return mock.GetMemoriesFunc(ctx, req)
+}
+
+// GetMemoriesCalls gets all the calls that were made to GetMemories.
+// Check the length with:
+//
+//	len(mockedMemoryStore.GetMemoriesCalls())
+func (mock *MemoryStoreMock) GetMemoriesCalls() []struct {
+	Ctx context.Context
+	Req *mem0.GetMemoriesRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *mem0.GetMemoriesRequest
+	}
+	mock.lockGetMemories.RLock()
+	calls = mock.calls.GetMemories
+	mock.lockGetMemories.RUnlock()
+	return calls
+}
+
+// GetMemory calls GetMemoryFunc.
+func (mock *MemoryStoreMock) GetMemory(ctx context.Context, memoryID string) (*mem0.Memory, error) {
+	if mock.GetMemoryFunc == nil {
+		panic("MemoryStoreMock.GetMemoryFunc: method is nil but MemoryStore.GetMemory was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		MemoryID string
+	}{
+		Ctx:      ctx,
+		MemoryID: memoryID,
+	}
+	mock.lockGetMemory.Lock()
+	mock.calls.GetMemory = append(mock.calls.GetMemory, callInfo)
+	mock.lockGetMemory.Unlock()
+	return mock.GetMemoryFunc(ctx, memoryID)
+}
+
+// GetMemoryCalls gets all the calls that were made to GetMemory.
+// Check the length with:
+//
+//	len(mockedMemoryStore.GetMemoryCalls())
+func (mock *MemoryStoreMock) GetMemoryCalls() []struct {
+	Ctx      context.Context
+	MemoryID string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		MemoryID string
+	}
+	mock.lockGetMemory.RLock()
+	calls = mock.calls.GetMemory
+	mock.lockGetMemory.RUnlock()
+	return calls
+}
+
+// GetMemoryHistory calls GetMemoryHistoryFunc.
+func (mock *MemoryStoreMock) GetMemoryHistory(ctx context.Context, memoryID string) ([]mem0.MemoryHistory, error) {
+	if mock.GetMemoryHistoryFunc == nil {
+		panic("MemoryStoreMock.GetMemoryHistoryFunc: method is nil but MemoryStore.GetMemoryHistory was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		MemoryID string
+	}{
+		Ctx:      ctx,
+		MemoryID: memoryID,
+	}
+	mock.lockGetMemoryHistory.Lock()
+	mock.calls.GetMemoryHistory = append(mock.calls.GetMemoryHistory, callInfo)
+	mock.lockGetMemoryHistory.Unlock()
+	return mock.GetMemoryHistoryFunc(ctx, memoryID)
+}
+
+// GetMemoryHistoryCalls gets all the calls that were made to GetMemoryHistory.
+// Check the length with:
+//
+//	len(mockedMemoryStore.GetMemoryHistoryCalls())
+func (mock *MemoryStoreMock) GetMemoryHistoryCalls() []struct {
+	Ctx      context.Context
+	MemoryID string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		MemoryID string
+	}
+	mock.lockGetMemoryHistory.RLock()
+	calls = mock.calls.GetMemoryHistory
+	mock.lockGetMemoryHistory.RUnlock()
+	return calls
+}
+
+// ListEntities calls ListEntitiesFunc.
+func (mock *MemoryStoreMock) ListEntities(ctx context.Context, req *mem0.ListEntitiesRequest) (*mem0.ListEntitiesResponse, error) {
+	if mock.ListEntitiesFunc == nil {
+		panic("MemoryStoreMock.ListEntitiesFunc: method is nil but MemoryStore.ListEntities was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *mem0.ListEntitiesRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockListEntities.Lock()
+	mock.calls.ListEntities = append(mock.calls.ListEntities, callInfo)
+	mock.lockListEntities.Unlock()
+	return mock.ListEntitiesFunc(ctx, req)
+}
+
+// ListEntitiesCalls gets all the calls that were made to ListEntities.
+// Check the length with:
+//
+//	len(mockedMemoryStore.ListEntitiesCalls())
+func (mock *MemoryStoreMock) ListEntitiesCalls() []struct {
+	Ctx context.Context
+	Req *mem0.ListEntitiesRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *mem0.ListEntitiesRequest
+	}
+	mock.lockListEntities.RLock()
+	calls = mock.calls.ListEntities
+	mock.lockListEntities.RUnlock()
+	return calls
+}
+
+// Search calls SearchFunc.
+func (mock *MemoryStoreMock) Search(ctx context.Context, req *mem0.SearchRequest) (*mem0.SearchResponse, error) {
+	if mock.SearchFunc == nil {
+		panic("MemoryStoreMock.SearchFunc: method is nil but MemoryStore.Search was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req *mem0.SearchRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockSearch.Lock()
+	mock.calls.Search = append(mock.calls.Search, callInfo)
+	mock.lockSearch.Unlock()
+	return mock.SearchFunc(ctx, req)
+}
+
+// SearchCalls gets all the calls that were made to Search.
+// Check the length with:
+//
+//	len(mockedMemoryStore.SearchCalls())
+func (mock *MemoryStoreMock) SearchCalls() []struct {
+	Ctx context.Context
+	Req *mem0.SearchRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req *mem0.SearchRequest
+	}
+	mock.lockSearch.RLock()
+	calls = mock.calls.Search
+	mock.lockSearch.RUnlock()
+	return calls
+}
+
+// UpdateMemory calls UpdateMemoryFunc.
+func (mock *MemoryStoreMock) UpdateMemory(ctx context.Context, memoryID string, req *mem0.UpdateMemoryRequest) (*mem0.Memory, error) {
+	if mock.UpdateMemoryFunc == nil {
+		panic("MemoryStoreMock.UpdateMemoryFunc: method is nil but MemoryStore.UpdateMemory was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		MemoryID string
+		Req      *mem0.UpdateMemoryRequest
+	}{
+		Ctx:      ctx,
+		MemoryID: memoryID,
+		Req:      req,
+	}
+	mock.lockUpdateMemory.Lock()
+	mock.calls.UpdateMemory = append(mock.calls.UpdateMemory, callInfo)
+	mock.lockUpdateMemory.Unlock()
+	return mock.UpdateMemoryFunc(ctx, memoryID, req)
+}
+
+// UpdateMemoryCalls gets all the calls that were made to UpdateMemory.
+// Check the length with:
+//
+//	len(mockedMemoryStore.UpdateMemoryCalls())
+func (mock *MemoryStoreMock) UpdateMemoryCalls() []struct {
+	Ctx      context.Context
+	MemoryID string
+	Req      *mem0.UpdateMemoryRequest
+} {
+	var calls []struct {
+		Ctx      context.Context
+		MemoryID string
+		Req      *mem0.UpdateMemoryRequest
+	}
+	mock.lockUpdateMemory.RLock()
+	calls = mock.calls.UpdateMemory
+	mock.lockUpdateMemory.RUnlock()
+	return calls
+}
@@ -0,0 +1,433 @@
+package mem0test
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	mem0 "github.com/alcova-ai/mem0-go"
+	"github.com/alcova-ai/mem0-go/internal/memstore"
+	"github.com/alcova-ai/mem0-go/internal/textsim"
+)
+
+// MaxBatchSize is the largest number of items the server accepts in one
+// batch update or delete, matching the platform limit.
+const MaxBatchSize = 1000
+
+const defaultTopK = 10
+
+const immutableDetail = "Immutable memories cannot be updated"
+
+func requireAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Token ") {
+			writeError(w, http.StatusUnauthorized, "Invalid API key")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, detail string) {
+	writeJSON(w, status, map[string]string{"detail": detail})
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func (s *Server) handleAdd(w http.ResponseWriter, r *http.Request) {
+	var req mem0.AddMemoriesRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if len(req.Messages) == 0 {
+		writeError(w, http.StatusBadRequest, "messages are required")
+		return
+	}
+	if req.UserID == "" && req.AgentID == "" && req.AppID == "" && req.RunID == "" {
+		writeError(w, http.StatusBadRequest, "One of user_id, agent_id, app_id or run_id is required")
+		return
+	}
+
+	// Without an LLM to extract facts, inference stores each user message
+	// verbatim; with inference off every message is stored.
+	infer := req.Infer == nil || *req.Infer
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var results []mem0.AddEvent
+	for _, msg := range req.Messages {
+		text := strings.TrimSpace(msg.Content)
+		if text == "" || infer && msg.Role != "user" {
+			continue
+		}
+		if s.mem.Duplicate(&req, text) {
+			continue
+		}
+
+		r := s.mem.Insert(mem0.Memory{
+			Memory:         text,
+			UserID:         req.UserID,
+			AgentID:        req.AgentID,
+			AppID:          req.AppID,
+			RunID:          req.RunID,
+			Metadata:       req.Metadata,
+			Immutable:      req.Immutable,
+			ExpirationDate: req.ExpirationDate,
+		}, nil)
+		results = append(results, mem0.AddEvent{ID: r.ID, Event: string(mem0.EventAdd), Memory: r.Memory.Memory})
+	}
+
+	if req.AsyncMode != nil && *req.AsyncMode {
+		event := &mem0.Event{
+			ID:        s.mem.NextID("evt"),
+			EventType: string(mem0.EventAdd),
+			Status:    mem0.EventStatusSucceeded,
+			CreatedAt: s.now(),
+			UpdatedAt: s.now(),
+		}
+		for _, res := range results {
+			er := mem0.EventResult{ID: res.ID, Event: res.Event, UserID: req.UserID}
+			er.Data.Memory = res.Memory
+			event.Results = append(event.Results, er)
+		}
+		s.events[event.ID] = event
+
+		writeJSON(w, http.StatusOK, mem0.AddMemoriesResponse{Results: []mem0.AddEvent{{
+			EventID: event.ID,
+			Status:  string(mem0.EventStatusPending),
+			Message: "Memory processing has been queued for background execution",
+		}}})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, mem0.AddMemoriesResponse{Results: results})
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, ok := s.mem.Get(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "Memory not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, memstore.CloneMemory(&m.Memory))
+}
+
+func (s *Server) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	var req mem0.UpdateMemoryRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m, ok := s.mem.Get(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "Memory not found")
+		return
+	}
+	if !s.mem.Update(m, req.Text, req.Metadata, nil) {
+		writeError(w, http.StatusBadRequest, immutableDetail)
+		return
+	}
+	writeJSON(w, http.StatusOK, memstore.CloneMemory(&m.Memory))
+}
+
+func (s *Server) handleDelete(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.mem.Remove(r.PathValue("id")) {
+		writeError(w, http.StatusNotFound, "Memory not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Memory deleted successfully!"})
+}
+
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	history, ok := s.mem.History(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "Memory not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, history)
+}
+
+type filtersBody struct {
+	Filters mem0.Filters `json:"filters"`
+}
+
+func (s *Server) handleDeleteAll(w http.ResponseWriter, r *http.Request) {
+	var req filtersBody
+	if !decode(w, r, &req) {
+		return
+	}
+	if len(req.Filters) == 0 {
+		writeError(w, http.StatusBadRequest, "filters are required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	matched, err := s.mem.Match(req.Filters)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	for _, m := range matched {
+		s.mem.Remove(m.ID)
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Memories deleted successfully!"})
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	var req filtersBody
+	if !decode(w, r, &req) {
+		return
+	}
+	if len(req.Filters) == 0 {
+		writeError(w, http.StatusBadRequest, "filters are required")
+		return
+	}
+
+	s.mu.Lock()
+	matched, err := s.match(req.Filters)
+	s.mu.Unlock()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	q := r.URL.Query()
+	if q.Get("page") == "" && q.Get("page_size") == "" {
+		writeJSON(w, http.StatusOK, matched)
+		return
+	}
+	writePage(w, r, matched)
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	var req mem0.SearchRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Query == "" {
+		writeError(w, http.StatusBadRequest, "query is required")
+		return
+	}
+	if len(req.Filters) == 0 {
+		writeError(w, http.StatusBadRequest, "filters are required")
+		return
+	}
+
+	s.mu.Lock()
+	matched, err := s.match(req.Filters)
+	s.mu.Unlock()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	terms := textsim.WordSet(req.Query)
+	results := make([]mem0.Memory, 0, len(matched))
+	for _, m := range matched {
+		m.Score = score(terms, m.Memory)
+		if m.Score == 0 || m.Score < req.Threshold {
+			continue
+		}
+		results = append(results, m)
+	}
+	slices.SortStableFunc(results, func(a, b mem0.Memory) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+
+	topK := req.TopK
+	if topK <= 0 {
+		topK = defaultTopK
+	}
+	if len(results) > topK {
+		results = results[:topK]
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+func (s *Server) handleBatchUpdate(w http.ResponseWriter, r *http.Request) {
+	var req mem0.BatchUpdateRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if len(req.Memories) > MaxBatchSize {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Cannot process more than %d memories at once", MaxBatchSize))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Check every item first so a rejected batch changes nothing.
+	for _, item := range req.Memories {
+		m, ok := s.mem.Get(item.MemoryID)
+		if !ok {
+			writeError(w, http.StatusNotFound, "Memory not found: "+item.MemoryID)
+			return
+		}
+		if m.Immutable {
+			writeError(w, http.StatusBadRequest, immutableDetail)
+			return
+		}
+	}
+	for _, item := range req.Memories {
+		m, _ := s.mem.Get(item.MemoryID)
+		s.mem.Update(m, item.Text, item.Metadata, nil)
+	}
+	writeJSON(w, http.StatusOK, map[string]string{
+		"message": fmt.Sprintf("Successfully updated %d memories", len(req.Memories)),
+	})
+}
+
+func (s *Server) handleBatchDelete(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Memories []struct {
+			MemoryID string `json:"memory_id"`
+		} `json:"memories"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	if len(req.Memories) > MaxBatchSize {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Cannot process more than %d memories at once", MaxBatchSize))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, item := range req.Memories {
+		if _, ok := s.mem.Get(item.MemoryID); !ok {
+			writeError(w, http.StatusNotFound, "Memory not found: "+item.MemoryID)
+			return
+		}
+	}
+	for _, item := range req.Memories {
+		s.mem.Remove(item.MemoryID)
+	}
+	writeJSON(w, http.StatusOK, map[string]string{
+		"message": fmt.Sprintf("Successfully deleted %d memories", len(req.Memories)),
+	})
+}
+
+func (s *Server) handleListEntities(w http.ResponseWriter, r *http.Request) {
+	typ := mem0.EntityType(r.URL.Query().Get("type"))
+
+	s.mu.Lock()
+	entities := s.mem.Entities(typ)
+	s.mu.Unlock()
+
+	writePage(w, r, entities)
+}
+
+func (s *Server) handleDeleteEntity(w http.ResponseWriter, r *http.Request) {
+	typ, id := mem0.EntityType(r.PathValue("type")), r.PathValue("id")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := s.mem.EntityMemories(typ, id)
+	if len(ids) == 0 {
+		writeError(w, http.StatusNotFound, "Entity not found")
+		return
+	}
+	for _, mid := range ids {
+		s.mem.Remove(mid)
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "Entity deleted successfully!"})
+}
+
+func (s *Server) handleGetEvent(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	event, ok := s.events[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Event not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, event)
+}
+
+// writePage writes items in the paginated envelope used by list endpoints,
+// honouring the page and page_size query parameters.
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	q := r.URL.Query()
+	page, _ := strconv.Atoi(q.Get("page"))
+	size, _ := strconv.Atoi(q.Get("page_size"))
+	results, page, size := memstore.Paginate(items, max(page, 1), size)
+
+	var next *string
+	if page*size < len(items) {
+		u := *r.URL
+		q.Set("page", strconv.Itoa(page+1))
+		u.RawQuery = q.Encode()
+		n := u.String()
+		next = &n
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"count":   len(items),
+		"next":    next,
+		"results": results,
+	})
+}
+
+// match returns copies of the stored memories satisfying filters in
+// insertion order. Callers hold s.mu.
+func (s *Server) match(filters mem0.Filters) ([]mem0.Memory, error) {
+	records, err := s.mem.Match(filters)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]mem0.Memory, len(records))
+	for i, r := range records {
+		out[i] = r.Memory
+	}
+	return out, nil
+}
+
+// score returns the fraction of query terms present in text.
+func score(query map[string]bool, text string) float64 {
+	if len(query) == 0 {
+		return 0
+	}
+	words := textsim.WordSet(text)
+	hits := 0
+	for t := range query {
+		if words[t] {
+			hits++
+		}
+	}
+	return float64(hits) / float64(len(query))
+}
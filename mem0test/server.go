@@ -0,0 +1,117 @@
+// Package mem0test provides an in-process fake of the mem0 platform API for
+// tests.
+//
+// The fake keeps real in-memory state: memories added through the client
+// can be read back, searched, updated and deleted, filters are evaluated
+// with the platform's v2 semantics, and every change is recorded in the
+// memory's history.
+//
+//	srv := mem0test.NewServer()
+//	defer srv.Close()
+//
+//	client := srv.Client()
+//	client.AddMemory(ctx, "Likes ramen", mem0.WithUserID("u1"))
+//	resp, _ := client.SearchUserMemories(ctx, "u1", "ramen")
+//
+// Search uses simple lexical scoring rather than embeddings: the score is
+// the fraction of query terms found in the memory text.
+package mem0test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	mem0 "github.com/alcova-ai/mem0-go"
+	"github.com/alcova-ai/mem0-go/internal/memstore"
+)
+
+// Server is a fake mem0 API server. It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	now func() time.Time
+
+	mu     sync.Mutex
+	mem    *memstore.Store
+	events map[string]*mem0.Event
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithClock sets the function used to timestamp memories and history.
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) { s.now = now }
+}
+
+// NewServer starts a fake mem0 server. Call Close when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		now:    time.Now,
+		events: make(map[string]*mem0.Event),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.mem = memstore.New(s.now)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/memories/{$}", s.handleAdd)
+	mux.HandleFunc("DELETE /v1/memories/all/", s.handleDeleteAll)
+	mux.HandleFunc("GET /v1/memories/{id}/", s.handleGet)
+	mux.HandleFunc("PUT /v1/memories/{id}/", s.handleUpdate)
+	mux.HandleFunc("DELETE /v1/memories/{id}/", s.handleDelete)
+	mux.HandleFunc("GET /v1/memories/{id}/history/", s.handleHistory)
+	mux.HandleFunc("POST /v2/memories/{$}", s.handleList)
+	mux.HandleFunc("POST /v2/memories/search/", s.handleSearch)
+	mux.HandleFunc("PUT /v1/batch/{$}", s.handleBatchUpdate)
+	mux.HandleFunc("DELETE /v1/batch/{$}", s.handleBatchDelete)
+	mux.HandleFunc("GET /v1/entities/{$}", s.handleListEntities)
+	mux.HandleFunc("DELETE /v2/entities/{type}/{id}/", s.handleDeleteEntity)
+	mux.HandleFunc("GET /v1/event/{id}/", s.handleGetEvent)
+
+	s.Server = httptest.NewServer(requireAuth(mux))
+	return s
+}
+
+// Client returns a mem0 client pointed at the server.
+func (s *Server) Client(opts ...mem0.ClientOption) *mem0.Client {
+	opts = append([]mem0.ClientOption{mem0.WithBaseURL(s.URL)}, opts...)
+	c, _ := mem0.NewClient("mem0test", opts...)
+	return c
+}
+
+// Seed stores memories directly, bypassing the API. Memories without an ID
+// are assigned one; zero timestamps are set to the current time.
+func (s *Server) Seed(memories ...mem0.Memory) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, m := range memories {
+		s.mem.Insert(m, nil)
+	}
+}
+
+// Memories returns a snapshot of every stored memory in insertion order.
+func (s *Server) Memories() []mem0.Memory {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records := s.mem.All()
+	out := make([]mem0.Memory, len(records))
+	for i, r := range records {
+		out[i] = memstore.CloneMemory(&r.Memory)
+	}
+	return out
+}
+
+// Reset removes all state.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.mem = memstore.New(s.now)
+	s.events = make(map[string]*mem0.Event)
+}
@@ -0,0 +1,204 @@
+package mem0test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mem0 "github.com/alcova-ai/mem0-go"
+)
+
+func TestServerCRUD(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	client := srv.Client()
+	ctx := context.Background()
+
+	add, err := client.AddMemory(ctx, "Likes spicy ramen", mem0.WithUserID("u1"))
+	if err != nil {
+		t.Fatalf("AddMemory: unexpected error: %v", err)
+	}
+	if len(add.Results) != 1 || add.Results[0].Event != "ADD" {
+		t.Fatalf("AddMemory: unexpected results %+v", add.Results)
+	}
+	id := add.Results[0].ID
+
+	mem, err := client.GetMemory(ctx, id)
+	if err != nil {
+		t.Fatalf("GetMemory: unexpected error: %v", err)
+	}
+	if mem.Memory != "Likes spicy ramen" || mem.UserID != "u1" {
+		t.Errorf("GetMemory: unexpected memory %+v", mem)
+	}
+
+	if _, err := client.UpdateMemory(ctx, id, &mem0.UpdateMemoryRequest{Text: "Likes mild ramen"}); err != nil {
+		t.Fatalf("UpdateMemory: unexpected error: %v", err)
+	}
+	if err := client.DeleteMemory(ctx, id); err != nil {
+		t.Fatalf("DeleteMemory: unexpected error: %v", err)
+	}
+
+	_, err = client.GetMemory(ctx, id)
+	var apiErr *mem0.APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("GetMemory after delete: expected not found, got %v", err)
+	}
+
+	history, err := client.GetMemoryHistory(ctx, id)
+	if err != nil {
+		t.Fatalf("GetMemoryHistory: unexpected error: %v", err)
+	}
+	events := make([]string, len(history))
+	for i, h := range history {
+		events[i] = string(h.Event)
+	}
+	if len(history) != 3 || events[0] != "ADD" || events[1] != "UPDATE" || events[2] != "DELETE" {
+		t.Fatalf("GetMemoryHistory: expected ADD, UPDATE, DELETE; got %v", events)
+	}
+	if history[1].OldMemory != "Likes spicy ramen" || history[1].NewMemory != "Likes mild ramen" {
+		t.Errorf("GetMemoryHistory: unexpected update entry %+v", history[1])
+	}
+}
+
+func TestServerSearchAndFilters(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.Seed(
+		mem0.Memory{Memory: "Enjoys hiking in the alps", UserID: "u1", Categories: []string{"travel"}, CreatedAt: base},
+		mem0.Memory{Memory: "Prefers aisle seats when flying", UserID: "u1", Categories: []string{"travel"}, CreatedAt: base.AddDate(0, 1, 0)},
+		mem0.Memory{Memory: "Allergic to peanuts", UserID: "u1", Categories: []string{"health"}, CreatedAt: base.AddDate(0, 2, 0)},
+		mem0.Memory{Memory: "Enjoys hiking too", UserID: "u2"},
+	)
+
+	client := srv.Client()
+	ctx := context.Background()
+
+	resp, err := client.SearchUserMemories(ctx, "u1", "hiking trips", mem0.WithTopK(5))
+	if err != nil {
+		t.Fatalf("Search: unexpected error: %v", err)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].Memory != "Enjoys hiking in the alps" {
+		t.Fatalf("Search: unexpected results %+v", resp.Results)
+	}
+	if resp.Results[0].Score != 0.5 {
+		t.Errorf("Search: expected score 0.5, got %v", resp.Results[0].Score)
+	}
+
+	got, err := client.GetMemories(ctx, &mem0.GetMemoriesRequest{
+		Filters: mem0.NewFilters().
+			WithUserID("u1").
+			WithCategories("travel").
+			WithCreatedAfter(base.AddDate(0, 0, 15)),
+	})
+	if err != nil {
+		t.Fatalf("GetMemories: unexpected error: %v", err)
+	}
+	if len(got.Results) != 1 || got.Results[0].Memory != "Prefers aisle seats when flying" {
+		t.Errorf("GetMemories: unexpected results %+v", got.Results)
+	}
+
+	either := mem0.NewFilters().WithUserID("u2").Or(mem0.NewFilters().WithCategoryContains("health"))
+	got, err = client.GetMemories(ctx, &mem0.GetMemoriesRequest{Filters: either})
+	if err != nil {
+		t.Fatalf("GetMemories OR: unexpected error: %v", err)
+	}
+	if len(got.Results) != 2 {
+		t.Errorf("GetMemories OR: expected 2 results, got %+v", got.Results)
+	}
+}
+
+func TestServerEntitiesAndPagination(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	for _, u := range []string{"u1", "u2", "u3"} {
+		srv.Seed(mem0.Memory{Memory: "fact about " + u, UserID: u, AgentID: "agent"})
+	}
+
+	client := srv.Client()
+	ctx := context.Background()
+
+	var users []string
+	for e, err := range client.AllEntities(ctx, mem0.EntityTypeUser, mem0.WithPageSize(2)) {
+		if err != nil {
+			t.Fatalf("AllEntities: unexpected error: %v", err)
+		}
+		users = append(users, e.ID)
+	}
+	if len(users) != 3 {
+		t.Errorf("AllEntities: expected 3 users, got %v", users)
+	}
+
+	if err := client.DeleteUser(ctx, "u2"); err != nil {
+		t.Fatalf("DeleteUser: unexpected error: %v", err)
+	}
+
+	n := 0
+	for _, err := range client.AllMemories(ctx, mem0.NewFilters().WithAgentID("agent"), mem0.WithPageSize(1)) {
+		if err != nil {
+			t.Fatalf("AllMemories: unexpected error: %v", err)
+		}
+		n++
+	}
+	if n != 2 {
+		t.Errorf("AllMemories: expected 2 memories after deleting u2, got %d", n)
+	}
+}
+
+func TestServerBatchAndAsync(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.Seed(mem0.Memory{ID: "a", Memory: "one", UserID: "u1"}, mem0.Memory{ID: "b", Memory: "two", UserID: "u1"})
+
+	client := srv.Client()
+	ctx := context.Background()
+
+	if _, err := client.BatchUpdate(ctx, &mem0.BatchUpdateRequest{
+		Memories: []mem0.BatchUpdateItem{{MemoryID: "a", Text: "uno"}},
+	}); err != nil {
+		t.Fatalf("BatchUpdate: unexpected error: %v", err)
+	}
+	if _, err := client.BatchDelete(ctx, &mem0.BatchDeleteRequest{MemoryIDs: []string{"b"}}); err != nil {
+		t.Fatalf("BatchDelete: unexpected error: %v", err)
+	}
+	if mems := srv.Memories(); len(mems) != 1 || mems[0].Memory != "uno" {
+		t.Errorf("unexpected memories after batch: %+v", mems)
+	}
+
+	resp, err := client.AddMemory(ctx, "Lives in Lisbon", mem0.WithUserID("u1"), mem0.WithAsync(true))
+	if err != nil {
+		t.Fatalf("AddMemory async: unexpected error: %v", err)
+	}
+	ops, err := client.WaitForEvents(ctx, resp, &mem0.WaitOptions{PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("WaitForEvents: unexpected error: %v", err)
+	}
+	if len(ops) != 1 || ops[0].Memory != "Lives in Lisbon" {
+		t.Errorf("WaitForEvents: unexpected operations %+v", ops)
+	}
+}
+
+func TestServerBatchUpdateImmutable(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.Seed(mem0.Memory{ID: "a", Memory: "one", UserID: "u1"}, mem0.Memory{ID: "b", Memory: "two", UserID: "u1", Immutable: true})
+
+	res, err := srv.Client().BatchUpdate(context.Background(), &mem0.BatchUpdateRequest{
+		Memories: []mem0.BatchUpdateItem{{MemoryID: "a", Text: "uno"}, {MemoryID: "b", Text: "dos"}},
+	})
+	if err == nil {
+		t.Fatal("BatchUpdate: expected error")
+	}
+	if len(res.Failed) != 2 {
+		t.Errorf("expected both items to fail, got %+v", res)
+	}
+	if mems := srv.Memories(); mems[0].Memory != "one" || mems[1].Memory != "two" {
+		t.Errorf("expected a rejected batch to change nothing, got %+v", mems)
+	}
+}
@@ -0,0 +1,193 @@
+// Package memcontext renders memories into a prompt block that fits a token
+// budget.
+//
+// Memories are ordered by score (or recency), added until the budget is
+// spent, grouped by category and rendered with a [Template]. The IDs of the
+// memories that made it in are returned for attribution:
+//
+//	resp, _ := client.SearchUserMemories(ctx, userID, query)
+//	block := memcontext.Build(resp.Results, 500, nil, memcontext.WithTemplate(memcontext.XML))
+//	system := basePrompt + "\n\n" + block.Text
+package memcontext
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	mem0 "github.com/alcova-ai/mem0-go"
+)
+
+// TokenEstimator estimates how many tokens a model's tokenizer produces for
+// a text. Estimates should err on the high side so that rendered blocks
+// stay within budget.
+type TokenEstimator interface {
+	EstimateTokens(text string) int
+}
+
+// EstimatorFunc adapts a function to a TokenEstimator.
+type EstimatorFunc func(text string) int
+
+func (f EstimatorFunc) EstimateTokens(text string) int { return f(text) }
+
+// CharEstimator estimates tokens from the number of characters. A
+// CharsPerToken of zero uses 4, a common rule of thumb for English text.
+type CharEstimator struct {
+	CharsPerToken float64
+}
+
+func (e CharEstimator) EstimateTokens(text string) int {
+	per := e.CharsPerToken
+	if per <= 0 {
+		per = 4
+	}
+	return int(math.Ceil(float64(utf8.RuneCountInString(text)) / per))
+}
+
+// Order selects which memories are kept first when the budget runs out.
+type Order int
+
+const (
+	// ByScore orders by search score, most relevant first, breaking ties
+	// by recency.
+	ByScore Order = iota
+	// ByRecency orders by last update (or creation) time, newest first.
+	ByRecency
+)
+
+type config struct {
+	order    Order
+	template Template
+	grouped  bool
+}
+
+// Option configures Build.
+type Option func(*config)
+
+// WithOrder sets the order in which memories are considered. Defaults to
+// ByScore.
+func WithOrder(o Order) Option {
+	return func(c *config) { c.order = o }
+}
+
+// WithTemplate sets the template used to render the block. Defaults to
+// Plain.
+func WithTemplate(t Template) Option {
+	return func(c *config) { c.template = t }
+}
+
+// WithoutGrouping renders memories as one list in priority order instead
+// of grouping them by category.
+func WithoutGrouping() Option {
+	return func(c *config) { c.grouped = false }
+}
+
+// Result is a rendered block.
+type Result struct {
+	Text string
+	// IDs holds the IDs of the included memories, in priority order.
+	IDs []string
+	// Omitted is the number of memories left out to fit the budget.
+	Omitted int
+	// Tokens is the estimated size of Text.
+	Tokens int
+}
+
+// Build renders as many memories as fit in budget tokens, as measured by
+// est. A budget of zero or less is unlimited; a nil est uses CharEstimator.
+// Memories are added in priority order and the first one that does not fit
+// ends the block, so a lower-priority memory never displaces a
+// higher-priority one. The rendered block includes a marker for the number
+// of memories omitted.
+func Build(memories []mem0.Memory, budget int, est TokenEstimator, opts ...Option) *Result {
+	o := config{template: Plain, grouped: true}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if est == nil {
+		est = CharEstimator{}
+	}
+
+	ordered := sortMemories(memories, o.order)
+	render := func(n int) string {
+		return o.template.Render(section(ordered[:n], len(ordered)-n, o.grouped))
+	}
+
+	n := 0
+	text := render(0)
+	for n < len(ordered) {
+		next := render(n + 1)
+		if budget > 0 && est.EstimateTokens(next) > budget {
+			break
+		}
+		n, text = n+1, next
+	}
+	if budget > 0 && est.EstimateTokens(text) > budget {
+		// Not even the empty block fits.
+		text = ""
+	}
+
+	ids := make([]string, n)
+	for i, m := range ordered[:n] {
+		ids[i] = m.ID
+	}
+	return &Result{
+		Text:    text,
+		IDs:     ids,
+		Omitted: len(ordered) - n,
+		Tokens:  est.EstimateTokens(text),
+	}
+}
+
+func sortMemories(memories []mem0.Memory, order Order) []mem0.Memory {
+	out := slices.Clone(memories)
+	byRecency := func(a, b mem0.Memory) int {
+		return updated(b).Compare(updated(a))
+	}
+	slices.SortStableFunc(out, func(a, b mem0.Memory) int {
+		if order == ByRecency {
+			return byRecency(a, b)
+		}
+		return cmp.Or(cmp.Compare(b.Score, a.Score), byRecency(a, b))
+	})
+	return out
+}
+
+// updated returns when m last changed.
+func updated(m mem0.Memory) time.Time {
+	if !m.UpdatedAt.IsZero() {
+		return m.UpdatedAt
+	}
+	return m.CreatedAt
+}
+
+// section groups memories by their first category, in order of each
+// category's highest-priority memory. Uncategorized memories form a group
+// with an empty category.
+func section(memories []mem0.Memory, omitted int, grouped bool) Section {
+	s := Section{Omitted: omitted}
+	if !grouped {
+		if len(memories) > 0 {
+			s.Groups = []Group{{Memories: memories}}
+		}
+		return s
+	}
+
+	index := make(map[string]int)
+	for _, m := range memories {
+		var category string
+		if len(m.Categories) > 0 {
+			category = m.Categories[0]
+		}
+		i, ok := index[category]
+		if !ok {
+			i = len(s.Groups)
+			index[category] = i
+			s.Groups = append(s.Groups, Group{Category: category})
+		}
+		s.Groups[i].Memories = append(s.Groups[i].Memories, m)
+	}
+	return s
+}
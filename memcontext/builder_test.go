@@ -0,0 +1,107 @@
+package memcontext
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	mem0 "github.com/alcova-ai/mem0-go"
+)
+
+var day = time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+var testMemories = []mem0.Memory{
+	{ID: "m1", Memory: "Allergic to peanuts", Categories: []string{"health"}, Score: 0.7, CreatedAt: day},
+	{ID: "m2", Memory: "Likes spicy ramen", Categories: []string{"food"}, Score: 0.9, CreatedAt: day.AddDate(0, 0, 1)},
+	{ID: "m3", Memory: "Vegetarian on weekdays", Categories: []string{"food"}, Score: 0.8, CreatedAt: day.AddDate(0, 0, 2)},
+	{ID: "m4", Memory: "Lives in Lisbon", Score: 0.8, CreatedAt: day.AddDate(0, 0, 3)},
+}
+
+// words counts whitespace-separated words, a predictable estimator for tests.
+var words = EstimatorFunc(func(s string) int { return len(strings.Fields(s)) })
+
+func TestBuildPlain(t *testing.T) {
+	res := Build(testMemories, 0, words)
+
+	// m4 and m3 tie on score; m4 is more recent.
+	want := "Relevant memories:\n" +
+		"\nfood:\n- Likes spicy ramen (2024-05-02)\n- Vegetarian on weekdays (2024-05-03)\n" +
+		"\nother:\n- Lives in Lisbon (2024-05-04)\n" +
+		"\nhealth:\n- Allergic to peanuts (2024-05-01)\n"
+	if res.Text != want {
+		t.Errorf("unexpected text:\n%s", res.Text)
+	}
+	if strings.Join(res.IDs, ",") != "m2,m4,m3,m1" || res.Omitted != 0 {
+		t.Errorf("expected m2,m4,m3,m1 with none omitted, got %v (%d omitted)", res.IDs, res.Omitted)
+	}
+	if res.Tokens != words(res.Text) {
+		t.Errorf("expected tokens %d, got %d", words(res.Text), res.Tokens)
+	}
+}
+
+func TestBuildBudget(t *testing.T) {
+	full := Build(testMemories, 0, words, WithoutGrouping())
+
+	// Dropping the last memory (5 words) adds a marker (4 words), so a
+	// budget 5 short also drops the one before it.
+	res := Build(testMemories, full.Tokens-5, words, WithoutGrouping())
+	if strings.Join(res.IDs, ",") != "m2,m4" || res.Omitted != 2 {
+		t.Errorf("expected m2,m4 with 2 omitted, got %v (%d omitted)", res.IDs, res.Omitted)
+	}
+	if res.Tokens > full.Tokens-5 {
+		t.Errorf("expected at most %d tokens, got %d", full.Tokens-5, res.Tokens)
+	}
+	if !strings.Contains(res.Text, "[2 more omitted]") {
+		t.Errorf("expected truncation marker, got:\n%s", res.Text)
+	}
+
+	res = Build(testMemories, 1, words)
+	if res.Text != "" || len(res.IDs) != 0 || res.Omitted != 4 {
+		t.Errorf("expected empty block for tiny budget, got %+v", res)
+	}
+}
+
+func TestBuildRecency(t *testing.T) {
+	res := Build(testMemories, 0, nil, WithOrder(ByRecency), WithoutGrouping())
+	if strings.Join(res.IDs, ",") != "m4,m3,m2,m1" {
+		t.Errorf("expected newest first, got %v", res.IDs)
+	}
+}
+
+func TestBuildXML(t *testing.T) {
+	memories := []mem0.Memory{{ID: "m1", Memory: `Says "a < b" & means it`}}
+	res := Build(memories, 0, nil, WithTemplate(XML))
+
+	want := "<memories>\n<memory id=\"m1\">Says &#34;a &lt; b&#34; &amp; means it</memory>\n</memories>\n"
+	if res.Text != want {
+		t.Errorf("unexpected text:\n%s", res.Text)
+	}
+
+	res = Build(testMemories, 0, words, WithTemplate(XML))
+	if !strings.Contains(res.Text, "<category name=\"food\">\n  <memory id=\"m2\" date=\"2024-05-02\">Likes spicy ramen</memory>") {
+		t.Errorf("expected grouped memories, got:\n%s", res.Text)
+	}
+}
+
+func TestBuildJSON(t *testing.T) {
+	full := Build(testMemories, 0, nil, WithTemplate(JSON))
+	res := Build(testMemories, full.Tokens-1, nil, WithTemplate(JSON))
+
+	var out struct {
+		Memories []struct {
+			ID       string `json:"id"`
+			Category string `json:"category"`
+		} `json:"memories"`
+		Omitted int `json:"omitted"`
+	}
+	if err := json.Unmarshal([]byte(res.Text), &out); err != nil {
+		t.Fatalf("expected valid JSON, got %v", err)
+	}
+	if len(out.Memories) != len(res.IDs) || out.Omitted != res.Omitted || res.Omitted == 0 {
+		t.Errorf("expected %d memories and %d omitted, got %+v", len(res.IDs), res.Omitted, out)
+	}
+	if out.Memories[0].ID != "m2" || out.Memories[0].Category != "food" {
+		t.Errorf("expected m2 in food first, got %+v", out.Memories[0])
+	}
+}
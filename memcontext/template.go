@@ -0,0 +1,156 @@
+package memcontext
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	mem0 "github.com/alcova-ai/mem0-go"
+)
+
+// Section is the input to a Template: the included memories, grouped,
+// and the number omitted to fit the budget.
+type Section struct {
+	Groups  []Group
+	Omitted int
+}
+
+// Group is a run of memories sharing a category. Category is empty for
+// uncategorized memories and when grouping is disabled.
+type Group struct {
+	Category string
+	Memories []mem0.Memory
+}
+
+// Template renders a Section as prompt text. Render is called repeatedly
+// while Build fills the budget, so it should be cheap and deterministic.
+// It should return "" for a section with no memories and none omitted.
+type Template interface {
+	Render(s Section) string
+}
+
+// TemplateFunc adapts a function to a Template.
+type TemplateFunc func(s Section) string
+
+func (f TemplateFunc) Render(s Section) string { return f(s) }
+
+// Built-in templates.
+var (
+	// Plain renders a bulleted list with a heading per category.
+	Plain Template = TemplateFunc(renderPlain)
+	// XML renders <memories> with a <memory> element per memory, the
+	// structure Claude is trained to attend to.
+	XML Template = TemplateFunc(renderXML)
+	// JSON renders a JSON object with a memories array.
+	JSON Template = TemplateFunc(renderJSON)
+)
+
+// uncategorized labels the group of memories without a category when
+// other groups have one.
+const uncategorized = "other"
+
+func empty(s Section) bool {
+	return len(s.Groups) == 0 && s.Omitted == 0
+}
+
+func headed(s Section) bool {
+	return len(s.Groups) > 1 || len(s.Groups) == 1 && s.Groups[0].Category != ""
+}
+
+func label(category string) string {
+	if category == "" {
+		return uncategorized
+	}
+	return category
+}
+
+func renderPlain(s Section) string {
+	if empty(s) {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("Relevant memories:\n")
+	for _, g := range s.Groups {
+		if headed(s) {
+			fmt.Fprintf(&b, "\n%s:\n", label(g.Category))
+		}
+		for _, m := range g.Memories {
+			fmt.Fprintf(&b, "- %s", m.Memory)
+			if t := updated(m); !t.IsZero() {
+				fmt.Fprintf(&b, " (%s)", t.Format("2006-01-02"))
+			}
+			b.WriteByte('\n')
+		}
+	}
+	if s.Omitted > 0 {
+		fmt.Fprintf(&b, "\n[%d more omitted]\n", s.Omitted)
+	}
+	return b.String()
+}
+
+func renderXML(s Section) string {
+	if empty(s) {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("<memories>\n")
+	for _, g := range s.Groups {
+		indent := ""
+		if headed(s) {
+			fmt.Fprintf(&b, "<category name=\"%s\">\n", escapeXML(label(g.Category)))
+			indent = "  "
+		}
+		for _, m := range g.Memories {
+			fmt.Fprintf(&b, "%s<memory id=\"%s\"", indent, escapeXML(m.ID))
+			if t := updated(m); !t.IsZero() {
+				fmt.Fprintf(&b, " date=\"%s\"", t.Format("2006-01-02"))
+			}
+			fmt.Fprintf(&b, ">%s</memory>\n", escapeXML(m.Memory))
+		}
+		if headed(s) {
+			b.WriteString("</category>\n")
+		}
+	}
+	if s.Omitted > 0 {
+		fmt.Fprintf(&b, "<truncated omitted=\"%d\"/>\n", s.Omitted)
+	}
+	b.WriteString("</memories>\n")
+	return b.String()
+}
+
+func escapeXML(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+type jsonMemory struct {
+	ID       string `json:"id"`
+	Memory   string `json:"memory"`
+	Category string `json:"category,omitempty"`
+	Date     string `json:"date,omitempty"`
+}
+
+type jsonSection struct {
+	Memories []jsonMemory `json:"memories"`
+	Omitted  int          `json:"omitted,omitempty"`
+}
+
+func renderJSON(s Section) string {
+	if empty(s) {
+		return ""
+	}
+	out := jsonSection{Memories: []jsonMemory{}, Omitted: s.Omitted}
+	for _, g := range s.Groups {
+		for _, m := range g.Memories {
+			jm := jsonMemory{ID: m.ID, Memory: m.Memory, Category: g.Category}
+			if t := updated(m); !t.IsZero() {
+				jm.Date = t.Format("2006-01-02")
+			}
+			out.Memories = append(out.Memories, jm)
+		}
+	}
+	data, _ := json.Marshal(out)
+	return string(data)
+}
@@ -1,8 +1,12 @@
 package mem0
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
+	"net/url"
+	"strconv"
 )
 
 type AddMemoriesRequest struct {
@@ -47,9 +51,10 @@ func (c *Client) AddMemories(ctx context.Context, req *AddMemoriesRequest) (*Add
 	}
 
 	var resp AddMemoriesResponse
-	if err := c.do(ctx, http.MethodPost, "/v1/memories/", nil, req, &resp); err != nil {
+	if err := c.do(ctx, "AddMemories", http.MethodPost, "/v1/memories/", nil, req, &resp); err != nil {
 		return nil, err
 	}
+	c.invalidateAdded(req.UserID, req.AgentID)
 
 	return &resp, nil
 }
@@ -102,13 +107,19 @@ func WithInfer(infer bool) AddMemoryOption {
 	return func(r *AddMemoriesRequest) { r.Infer = &infer }
 }
 
+// WithAsync processes the add in the background. Use WaitForEvents to wait
+// for the resulting memory operations.
+func WithAsync(async bool) AddMemoryOption {
+	return func(r *AddMemoriesRequest) { r.AsyncMode = &async }
+}
+
 func (c *Client) GetMemory(ctx context.Context, memoryID string) (*Memory, error) {
 	if memoryID == "" {
 		return nil, ErrMissingID
 	}
 
 	var mem Memory
-	if err := c.do(ctx, http.MethodGet, "/v1/memories/"+memoryID+"/", nil, nil, &mem); err != nil {
+	if err := c.do(ctx, "GetMemory", http.MethodGet, "/v1/memories/"+memoryID+"/", nil, nil, &mem); err != nil {
 		return nil, err
 	}
 
@@ -118,8 +129,8 @@ func (c *Client) GetMemory(ctx context.Context, memoryID string) (*Memory, error
 type GetMemoriesRequest struct {
 	Filters   Filters  `json:"filters"`
 	Fields    []string `json:"fields,omitempty"`
-	Page      int      `json:"page,omitempty"`
-	PageSize  int      `json:"page_size,omitempty"`
+	Page      int      `json:"-"` // sent as a query parameter
+	PageSize  int      `json:"-"` // sent as a query parameter
 	OrgID     string   `json:"org_id,omitempty"`
 	ProjectID string   `json:"project_id,omitempty"`
 }
@@ -129,12 +140,59 @@ type GetMemoriesResponse struct {
 	Page     int      `json:"page,omitempty"`
 	PageSize int      `json:"page_size,omitempty"`
 	Total    int      `json:"total,omitempty"`
+	Next     string   `json:"next,omitempty"` // URL of the next page, if any
+
+	paginated bool
+}
+
+// UnmarshalJSON accepts the bare array returned for unpaginated requests
+// as well as the paginated envelope, which reports the total as "count".
+func (r *GetMemoriesResponse) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		return json.Unmarshal(data, &r.Results)
+	}
+
+	var env struct {
+		Results  []Memory `json:"results"`
+		Page     int      `json:"page"`
+		PageSize int      `json:"page_size"`
+		Total    int      `json:"total"`
+		Count    int      `json:"count"`
+		Next     *string  `json:"next"`
+	}
+	if err := json.Unmarshal(data, &env); err != nil {
+		return err
+	}
+
+	*r = GetMemoriesResponse{
+		Results:   env.Results,
+		Page:      env.Page,
+		PageSize:  env.PageSize,
+		Total:     env.Total,
+		paginated: true,
+	}
+	if r.Total == 0 {
+		r.Total = env.Count
+	}
+	if env.Next != nil {
+		r.Next = *env.Next
+	}
+	return nil
+}
+
+// hasMore reports whether a page after this one may exist.
+func (r *GetMemoriesResponse) hasMore() bool {
+	return hasMorePages(r.paginated, r.Next, r.Page, r.PageSize, r.Total, len(r.Results))
 }
 
 func (c *Client) GetMemories(ctx context.Context, req *GetMemoriesRequest) (*GetMemoriesResponse, error) {
 	if req == nil || req.Filters == nil {
 		return nil, ErrMissingFilters
 	}
+	if err := validateFilters(req.Filters); err != nil {
+		return nil, err
+	}
 
 	if req.OrgID == "" && c.orgID != "" {
 		req.OrgID = c.orgID
@@ -143,12 +201,30 @@ func (c *Client) GetMemories(ctx context.Context, req *GetMemoriesRequest) (*Get
 		req.ProjectID = c.projectID
 	}
 
-	var results []Memory
-	if err := c.do(ctx, http.MethodPost, "/v2/memories/", nil, req, &results); err != nil {
+	var query url.Values
+	if req.Page > 0 || req.PageSize > 0 {
+		query = url.Values{}
+		if req.Page > 0 {
+			query.Set("page", strconv.Itoa(req.Page))
+		}
+		if req.PageSize > 0 {
+			query.Set("page_size", strconv.Itoa(req.PageSize))
+		}
+	}
+
+	var resp GetMemoriesResponse
+	if err := c.do(ctx, "GetMemories", http.MethodPost, "/v2/memories/", query, req, &resp); err != nil {
 		return nil, err
 	}
 
-	return &GetMemoriesResponse{Results: results}, nil
+	if resp.Page == 0 {
+		resp.Page = req.Page
+	}
+	if resp.PageSize == 0 {
+		resp.PageSize = req.PageSize
+	}
+
+	return &resp, nil
 }
 
 func (c *Client) GetUserMemories(ctx context.Context, userID string) (*GetMemoriesResponse, error) {
@@ -172,9 +248,10 @@ func (c *Client) UpdateMemory(ctx context.Context, memoryID string, req *UpdateM
 	}
 
 	var mem Memory
-	if err := c.do(ctx, http.MethodPut, "/v1/memories/"+memoryID+"/", nil, req, &mem); err != nil {
+	if err := c.do(ctx, "UpdateMemory", http.MethodPut, "/v1/memories/"+memoryID+"/", nil, req, &mem); err != nil {
 		return nil, err
 	}
+	c.invalidateUpdated(Memory{ID: memoryID, UserID: mem.UserID, AgentID: mem.AgentID})
 
 	return &mem, nil
 }
@@ -184,7 +261,11 @@ func (c *Client) DeleteMemory(ctx context.Context, memoryID string) error {
 		return ErrMissingID
 	}
 
-	return c.do(ctx, http.MethodDelete, "/v1/memories/"+memoryID+"/", nil, nil, nil)
+	if err := c.do(ctx, "DeleteMemory", http.MethodDelete, "/v1/memories/"+memoryID+"/", nil, nil, nil); err != nil {
+		return err
+	}
+	c.invalidateDeleted(memoryID)
+	return nil
 }
 
 type DeleteMemoriesRequest struct {
@@ -197,6 +278,9 @@ func (c *Client) DeleteMemories(ctx context.Context, req *DeleteMemoriesRequest)
 	if req == nil || req.Filters == nil {
 		return ErrMissingFilters
 	}
+	if err := validateFilters(req.Filters); err != nil {
+		return err
+	}
 
 	if req.OrgID == "" && c.orgID != "" {
 		req.OrgID = c.orgID
@@ -205,7 +289,17 @@ func (c *Client) DeleteMemories(ctx context.Context, req *DeleteMemoriesRequest)
 		req.ProjectID = c.projectID
 	}
 
-	return c.do(ctx, http.MethodDelete, "/v1/memories/all/", nil, req, nil)
+	if err := c.do(ctx, "DeleteMemories", http.MethodDelete, "/v1/memories/all/", nil, req, nil); err != nil {
+		return err
+	}
+	c.invalidateMatching(
+		cacheScope{userID: req.Filters.scopeValue("user_id"), agentID: req.Filters.scopeValue("agent_id")},
+		func(m *Memory) bool {
+			ok, err := req.Filters.Match(*m)
+			return ok || err != nil
+		},
+	)
+	return nil
 }
 
 func (c *Client) DeleteUserMemories(ctx context.Context, userID string) error {
@@ -221,7 +315,7 @@ func (c *Client) GetMemoryHistory(ctx context.Context, memoryID string) ([]Memor
 	}
 
 	var history []MemoryHistory
-	if err := c.do(ctx, http.MethodGet, "/v1/memories/"+memoryID+"/history/", nil, nil, &history); err != nil {
+	if err := c.do(ctx, "GetMemoryHistory", http.MethodGet, "/v1/memories/"+memoryID+"/history/", nil, nil, &history); err != nil {
 		return nil, err
 	}
 
@@ -238,21 +332,32 @@ type BatchUpdateRequest struct {
 	Memories []BatchUpdateItem `json:"memories"`
 }
 
-type BatchUpdateResponse struct {
-	Message string `json:"message"`
-}
-
-func (c *Client) BatchUpdate(ctx context.Context, req *BatchUpdateRequest) (*BatchUpdateResponse, error) {
+// BatchUpdate updates multiple memories, splitting requests larger than the
+// platform's per-request limit into chunks sent concurrently (see
+// WithBatchSize). It returns the per-ID outcome along with an error if any
+// ID failed.
+func (c *Client) BatchUpdate(ctx context.Context, req *BatchUpdateRequest) (*BatchResult, error) {
 	if req == nil || len(req.Memories) == 0 {
 		return nil, ErrEmptyRequest
 	}
 
-	var resp BatchUpdateResponse
-	if err := c.do(ctx, http.MethodPut, "/v1/batch/", nil, req, &resp); err != nil {
-		return nil, err
+	ids := make([]string, len(req.Memories))
+	for i, item := range req.Memories {
+		ids[i] = item.MemoryID
 	}
+	res := c.runBatch(ctx, ids, func(ctx context.Context, lo, hi int) error {
+		chunk := &BatchUpdateRequest{Memories: req.Memories[lo:hi]}
+		return c.do(ctx, "BatchUpdate", http.MethodPut, "/v1/batch/", nil, chunk, nil)
+	})
 
-	return &resp, nil
+	// A failed chunk may still have been applied, so every ID invalidates.
+	updated := make([]Memory, len(ids))
+	for i, id := range ids {
+		updated[i] = Memory{ID: id}
+	}
+	c.invalidateUpdated(updated...)
+
+	return res, res.Err()
 }
 
 type BatchDeleteRequest struct {
@@ -263,22 +368,46 @@ type batchDeleteItem struct {
 	MemoryID string `json:"memory_id"`
 }
 
-type batchDeleteBody struct {
-	Memories []batchDeleteItem `json:"memories"`
+// MarshalJSON encodes the request in the shape expected by the batch
+// endpoint: {"memories": [{"memory_id": "..."}]}.
+func (r BatchDeleteRequest) MarshalJSON() ([]byte, error) {
+	body := struct {
+		Memories []batchDeleteItem `json:"memories"`
+	}{
+		Memories: make([]batchDeleteItem, len(r.MemoryIDs)),
+	}
+	for i, id := range r.MemoryIDs {
+		body.Memories[i] = batchDeleteItem{MemoryID: id}
+	}
+	return json.Marshal(body)
 }
 
-// BatchDelete deletes multiple memories in a single request.
-func (c *Client) BatchDelete(ctx context.Context, req *BatchDeleteRequest) error {
-	if req == nil || len(req.MemoryIDs) == 0 {
-		return ErrEmptyRequest
+// UnmarshalJSON decodes the batch endpoint's shape written by MarshalJSON.
+func (r *BatchDeleteRequest) UnmarshalJSON(data []byte) error {
+	var body struct {
+		Memories []batchDeleteItem `json:"memories"`
 	}
-
-	body := batchDeleteBody{
-		Memories: make([]batchDeleteItem, len(req.MemoryIDs)),
+	if err := json.Unmarshal(data, &body); err != nil {
+		return err
 	}
-	for i, id := range req.MemoryIDs {
-		body.Memories[i] = batchDeleteItem{MemoryID: id}
+	r.MemoryIDs = make([]string, len(body.Memories))
+	for i, item := range body.Memories {
+		r.MemoryIDs[i] = item.MemoryID
 	}
+	return nil
+}
 
-	return c.do(ctx, http.MethodDelete, "/v1/batch/", nil, body, nil)
+// BatchDelete deletes multiple memories, chunking large requests like
+// BatchUpdate.
+func (c *Client) BatchDelete(ctx context.Context, req *BatchDeleteRequest) (*BatchResult, error) {
+	if req == nil || len(req.MemoryIDs) == 0 {
+		return nil, ErrEmptyRequest
+	}
+
+	res := c.runBatch(ctx, req.MemoryIDs, func(ctx context.Context, lo, hi int) error {
+		chunk := &BatchDeleteRequest{MemoryIDs: req.MemoryIDs[lo:hi]}
+		return c.do(ctx, "BatchDelete", http.MethodDelete, "/v1/batch/", nil, chunk, nil)
+	})
+	c.invalidateDeleted(req.MemoryIDs...)
+	return res, res.Err()
 }
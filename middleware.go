@@ -0,0 +1,46 @@
+package mem0
+
+import (
+	"context"
+	"net/http"
+)
+
+// Call describes a single attempt of an API operation as seen by middleware.
+type Call struct {
+	// Operation is the name of the client method, e.g. "Search" or
+	// "AddMemories". Convenience wrappers report the method they delegate to.
+	Operation string
+
+	// Request is the value sent as the JSON body, such as *SearchRequest,
+	// or nil for requests without a body. It has already been encoded into
+	// HTTPRequest; changing it has no effect on what is sent.
+	Request any
+
+	// Response is a pointer to the value the response body is decoded
+	// into, such as *SearchResponse, or nil if the body is discarded. It
+	// is populated once next returns without error.
+	Response any
+
+	// HTTPRequest is the outgoing request. Middleware may set headers or
+	// replace it before calling next.
+	HTTPRequest *http.Request
+
+	// HTTPResponse and ResponseBody are set once the request has been
+	// sent. The response body has already been read into ResponseBody.
+	HTTPResponse *http.Response
+	ResponseBody []byte
+}
+
+// RoundTripFunc sends a call and decodes its response.
+type RoundTripFunc func(ctx context.Context, call *Call) error
+
+// Middleware wraps a RoundTripFunc to add behaviour such as header
+// injection, request signing or logging.
+//
+//	tenant := func(next mem0.RoundTripFunc) mem0.RoundTripFunc {
+//	    return func(ctx context.Context, call *mem0.Call) error {
+//	        call.HTTPRequest.Header.Set("X-Tenant", tenantFrom(ctx))
+//	        return next(ctx, call)
+//	    }
+//	}
+type Middleware func(next RoundTripFunc) RoundTripFunc
@@ -0,0 +1,78 @@
+package mem0
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestMiddlewareChain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Tenant") != "acme" {
+			t.Errorf("expected X-Tenant header 'acme', got %q", r.Header.Get("X-Tenant"))
+		}
+		json.NewEncoder(w).Encode([]Memory{{ID: "mem-1", Memory: "likes ramen"}})
+	}))
+	defer server.Close()
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(ctx context.Context, call *Call) error {
+				order = append(order, name+">")
+				err := next(ctx, call)
+				order = append(order, "<"+name)
+				return err
+			}
+		}
+	}
+
+	var gotQuery string
+	var gotResults int
+	inspect := func(next RoundTripFunc) RoundTripFunc {
+		return func(ctx context.Context, call *Call) error {
+			if call.Operation != "Search" {
+				t.Errorf("expected operation 'Search', got %q", call.Operation)
+			}
+			if req, ok := call.Request.(*SearchRequest); ok {
+				gotQuery = req.Query
+			}
+			call.HTTPRequest.Header.Set("X-Tenant", "acme")
+			if err := next(ctx, call); err != nil {
+				return err
+			}
+			if resp, ok := call.Response.(*SearchResponse); ok {
+				gotResults = len(resp.Results)
+				resp.Results[0].Memory = "redacted"
+			}
+			return nil
+		}
+	}
+
+	client, _ := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithMiddleware(trace("a"), trace("b")),
+		WithMiddleware(inspect),
+	)
+
+	resp, err := client.SearchUserMemories(context.Background(), "user-1", "food")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"a>", "b>", "<b", "<a"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("expected order %v, got %v", want, order)
+	}
+	if gotQuery != "food" {
+		t.Errorf("expected typed request query 'food', got %q", gotQuery)
+	}
+	if gotResults != 1 {
+		t.Errorf("expected typed response with 1 result, got %d", gotResults)
+	}
+	if resp.Results[0].Memory != "redacted" {
+		t.Errorf("expected middleware to mutate response, got %q", resp.Results[0].Memory)
+	}
+}
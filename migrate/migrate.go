@@ -0,0 +1,262 @@
+// Package migrate copies memories between mem0 projects or organizations.
+//
+// Clients are bound to one org and project, so a migration takes a client
+// for each side:
+//
+//	staging, _ := mem0.NewClient(key, mem0.WithProjectID("staging"))
+//	prod, _ := mem0.NewClient(key, mem0.WithProjectID("prod"))
+//	report, err := migrate.Run(ctx, staging, prod, mem0.NewFilters().WithUserID("user-123"), nil)
+//
+// Each copy is annotated with a "migration" metadata object recording the
+// source memory's ID and timestamps, and optionally its change history.
+// Memories already annotated in the destination are skipped, so an
+// interrupted migration can be re-run.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	mem0 "github.com/alcova-ai/mem0-go"
+)
+
+// MetadataKey is the metadata key holding the migration annotation.
+const MetadataKey = "migration"
+
+// ErrUnverified is returned when the destination does not hold a copy of
+// every source memory after the migration.
+var ErrUnverified = errors.New("migrate: destination is missing migrated memories")
+
+// Options controls Run.
+type Options struct {
+	// DryRun lists and validates the source memories without copying.
+	DryRun bool
+	// History adds each source memory's change history to its annotation.
+	// It costs one extra request per memory.
+	History bool
+	// DeleteSource deletes the source memories once every copy has been
+	// verified in the destination.
+	DeleteSource bool
+	// Now returns the migration time recorded in annotations. Defaults to
+	// time.Now.
+	Now func() time.Time
+}
+
+// Annotation is the value stored under MetadataKey on each copy.
+type Annotation struct {
+	SourceID        string          `json:"source_id"`
+	SourceCreatedAt time.Time       `json:"source_created_at,omitzero"`
+	SourceUpdatedAt time.Time       `json:"source_updated_at,omitzero"`
+	MigratedAt      time.Time       `json:"migrated_at"`
+	History         []HistoryRecord `json:"history,omitempty"`
+}
+
+// HistoryRecord is a compact entry of a source memory's history.
+type HistoryRecord struct {
+	Event     mem0.HistoryEvent `json:"event"`
+	OldMemory string            `json:"old_memory,omitempty"`
+	NewMemory string            `json:"new_memory,omitempty"`
+	CreatedAt time.Time         `json:"created_at,omitzero"`
+}
+
+// Report describes a migration.
+type Report struct {
+	// Source is the number of memories matching the filters in the source.
+	Source int
+	// Copied is the number of memories copied by this run, or that would
+	// be copied in a dry run.
+	Copied int
+	// Skipped is the number of source memories already in the destination
+	// from an earlier run.
+	Skipped int
+	// IDMap maps source memory IDs to destination memory IDs, including
+	// skipped memories.
+	IDMap  map[string]string
+	Failed []*Failure
+
+	// Verified reports whether every source memory was found in the
+	// destination after copying. Missing lists the source IDs that were
+	// not.
+	Verified bool
+	Missing  []string
+	// Deleted is the number of source memories deleted.
+	Deleted int
+}
+
+// Failure reports a source memory that could not be copied.
+type Failure struct {
+	SourceID string
+	Err      error
+}
+
+func (f *Failure) Error() string {
+	return fmt.Sprintf("migrate: memory %s: %v", f.SourceID, f.Err)
+}
+
+func (f *Failure) Unwrap() error { return f.Err }
+
+// Err returns nil if every memory was copied, and otherwise an error
+// wrapping every failure.
+func (r *Report) Err() error {
+	if r == nil || len(r.Failed) == 0 {
+		return nil
+	}
+	errs := make([]error, len(r.Failed))
+	for i, f := range r.Failed {
+		errs[i] = f
+	}
+	return errors.Join(errs...)
+}
+
+// Run copies every memory matching filters from src to dst. It returns an
+// error if any memory failed to copy or the copies could not be verified;
+// the source is only deleted when neither happened.
+func Run(ctx context.Context, src, dst *mem0.Client, filters mem0.Filters, opts *Options) (*Report, error) {
+	if filters == nil {
+		return nil, mem0.ErrMissingFilters
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	now := opts.Now
+	if now == nil {
+		now = time.Now
+	}
+
+	report := &Report{IDMap: make(map[string]string)}
+	existing, err := migrated(ctx, dst, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each source memory is added verbatim in its original scope, as Import
+	// does, while the source is being listed.
+	migratedAt := now().UTC()
+	for m, err := range src.AllMemories(ctx, filters) {
+		if err != nil {
+			return nil, fmt.Errorf("migrate: list source: %w", err)
+		}
+		report.Source++
+		if id, ok := existing[m.ID]; ok {
+			report.Skipped++
+			report.IDMap[m.ID] = id
+			continue
+		}
+
+		ann := Annotation{
+			SourceID:        m.ID,
+			SourceCreatedAt: m.CreatedAt,
+			SourceUpdatedAt: m.UpdatedAt,
+			MigratedAt:      migratedAt,
+		}
+		if opts.History {
+			if ann.History, err = history(ctx, src, m.ID); err != nil {
+				return nil, err
+			}
+		}
+		m.Metadata = maps.Clone(m.Metadata)
+		if m.Metadata == nil {
+			m.Metadata = make(map[string]any)
+		}
+		m.Metadata[MetadataKey] = ann
+
+		if opts.DryRun {
+			if _, err := mem0.ImportRequest(m); err != nil {
+				report.Failed = append(report.Failed, &Failure{SourceID: m.ID, Err: err})
+			} else {
+				report.Copied++
+			}
+			continue
+		}
+		id, err := dst.ImportMemory(ctx, m)
+		if err != nil {
+			if ctx.Err() != nil {
+				return report, ctx.Err()
+			}
+			report.Failed = append(report.Failed, &Failure{SourceID: m.ID, Err: err})
+			continue
+		}
+		report.Copied++
+		report.IDMap[m.ID] = id
+	}
+	if opts.DryRun || len(report.Failed) > 0 {
+		return report, report.Err()
+	}
+
+	if err := verify(ctx, dst, filters, report); err != nil {
+		return report, err
+	}
+	if !report.Verified {
+		return report, ErrUnverified
+	}
+
+	if opts.DeleteSource && report.Source > 0 {
+		ids := slices.Sorted(maps.Keys(report.IDMap))
+		del, err := src.BatchDelete(ctx, &mem0.BatchDeleteRequest{MemoryIDs: ids})
+		if del != nil {
+			report.Deleted = len(del.Succeeded)
+		}
+		if err != nil {
+			return report, fmt.Errorf("migrate: delete source: %w", err)
+		}
+	}
+	return report, nil
+}
+
+// migrated maps source IDs to destination IDs for memories already copied
+// to dst by an earlier run.
+func migrated(ctx context.Context, dst *mem0.Client, filters mem0.Filters) (map[string]string, error) {
+	out := make(map[string]string)
+	for m, err := range dst.AllMemories(ctx, filters) {
+		if err != nil {
+			return nil, fmt.Errorf("migrate: list destination: %w", err)
+		}
+		if id := sourceID(m); id != "" {
+			out[id] = m.ID
+		}
+	}
+	return out, nil
+}
+
+// sourceID returns the source ID recorded in m's annotation, if any.
+func sourceID(m mem0.Memory) string {
+	ann, ok := m.Metadata[MetadataKey].(map[string]any)
+	if !ok {
+		return ""
+	}
+	id, _ := ann["source_id"].(string)
+	return id
+}
+
+func history(ctx context.Context, src *mem0.Client, id string) ([]HistoryRecord, error) {
+	entries, err := src.GetMemoryHistory(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("migrate: history of %s: %w", id, err)
+	}
+	out := make([]HistoryRecord, len(entries))
+	for i, h := range entries {
+		out[i] = HistoryRecord{Event: h.Event, OldMemory: h.OldMemory, NewMemory: h.NewMemory, CreatedAt: h.CreatedAt}
+	}
+	return out, nil
+}
+
+// verify checks that every source memory in report.IDMap has a copy in dst
+// annotated with its source ID.
+func verify(ctx context.Context, dst *mem0.Client, filters mem0.Filters, report *Report) error {
+	found, err := migrated(ctx, dst, filters)
+	if err != nil {
+		return err
+	}
+	report.Missing = nil
+	for _, srcID := range slices.Sorted(maps.Keys(report.IDMap)) {
+		if found[srcID] != report.IDMap[srcID] {
+			report.Missing = append(report.Missing, srcID)
+		}
+	}
+	report.Verified = len(report.Missing) == 0 && len(report.IDMap) == report.Source
+	return nil
+}
@@ -0,0 +1,106 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mem0 "github.com/alcova-ai/mem0-go"
+	"github.com/alcova-ai/mem0-go/mem0test"
+)
+
+var migratedAt = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func newServers(t *testing.T) (src, dst *mem0test.Server) {
+	t.Helper()
+	src, dst = mem0test.NewServer(), mem0test.NewServer()
+	t.Cleanup(src.Close)
+	t.Cleanup(dst.Close)
+
+	src.Seed(
+		mem0.Memory{ID: "s1", Memory: "Likes ramen", UserID: "u1", Metadata: map[string]any{"source": "chat"}},
+		mem0.Memory{ID: "s2", Memory: "Allergic to peanuts", UserID: "u1", Immutable: true},
+		mem0.Memory{ID: "s3", Memory: "Lives in Oslo", UserID: "u2"},
+	)
+	return src, dst
+}
+
+func TestRun(t *testing.T) {
+	src, dst := newServers(t)
+	ctx := context.Background()
+	filters := mem0.NewFilters().WithUserID("u1")
+	opts := &Options{History: true, DeleteSource: true, Now: func() time.Time { return migratedAt }}
+
+	report, err := Run(ctx, src.Client(), dst.Client(), filters, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Source != 2 || report.Copied != 2 || !report.Verified || report.Deleted != 2 {
+		t.Errorf("expected 2 copied, verified and deleted, got %+v", report)
+	}
+
+	copies := dst.Memories()
+	if len(copies) != 2 {
+		t.Fatalf("expected 2 copies, got %+v", copies)
+	}
+	ramen := copies[0]
+	if report.IDMap["s1"] != ramen.ID || ramen.UserID != "u1" || ramen.Metadata["source"] != "chat" {
+		t.Errorf("expected s1 copied with metadata, got %+v", ramen)
+	}
+	ann, _ := ramen.Metadata[MetadataKey].(map[string]any)
+	history, _ := ann["history"].([]any)
+	if ann["source_id"] != "s1" || ann["migrated_at"] != "2024-06-01T00:00:00Z" || len(history) != 1 {
+		t.Errorf("expected migration annotation with history, got %v", ann)
+	}
+	if !copies[1].Immutable {
+		t.Error("expected immutability to be preserved")
+	}
+
+	if left := src.Memories(); len(left) != 1 || left[0].ID != "s3" {
+		t.Errorf("expected only u2's memory left in source, got %+v", left)
+	}
+}
+
+func TestRunResumesAndDryRun(t *testing.T) {
+	src, dst := newServers(t)
+	ctx := context.Background()
+	filters := mem0.NewFilters().WithUserID("u1")
+
+	report, err := Run(ctx, src.Client(), dst.Client(), filters, &Options{DryRun: true})
+	if err != nil || report.Copied != 2 || len(dst.Memories()) != 0 {
+		t.Errorf("expected dry run to copy nothing, got %+v, %v", report, err)
+	}
+
+	if _, err := Run(ctx, src.Client(), dst.Client(), filters, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	report, err = Run(ctx, src.Client(), dst.Client(), filters, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Skipped != 2 || report.Copied != 0 || !report.Verified || len(dst.Memories()) != 2 {
+		t.Errorf("expected re-run to skip copied memories, got %+v", report)
+	}
+	if len(src.Memories()) != 3 {
+		t.Error("expected source to be kept without DeleteSource")
+	}
+}
+
+func TestRunReportsFailuresBySourceID(t *testing.T) {
+	src, dst := newServers(t)
+	src.Seed(mem0.Memory{ID: "s4", Memory: "  ", UserID: "u2"})
+	ctx := context.Background()
+
+	report, err := Run(ctx, src.Client(), dst.Client(), mem0.NewFilters().WithUserID("u2"), &Options{DeleteSource: true})
+	var failure *Failure
+	if !errors.As(err, &failure) || failure.SourceID != "s4" {
+		t.Fatalf("expected failure for s4, got %v", err)
+	}
+	if report.Copied != 1 || len(report.Failed) != 1 || report.IDMap["s3"] == "" {
+		t.Errorf("expected s3 copied and s4 failed, got %+v", report)
+	}
+	if report.Deleted != 0 || len(src.Memories()) != 4 {
+		t.Error("expected source to be kept after a failure")
+	}
+}
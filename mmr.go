@@ -0,0 +1,152 @@
+package mem0
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/alcova-ai/mem0-go/internal/textsim"
+)
+
+// Embedder turns text into a vector for similarity comparisons.
+// Implementations must return vectors of a fixed dimension.
+type Embedder interface {
+	Embed(ctx context.Context, text string) ([]float32, error)
+}
+
+// MMROptions configures maximal marginal relevance diversification of
+// search results.
+type MMROptions struct {
+	// Lambda trades relevance against diversity: 1 ranks purely by
+	// relevance, 0 purely by dissimilarity to results already selected.
+	Lambda float64
+	// FetchK is the number of candidates requested from the server before
+	// diversifying down to the request's TopK. Defaults to twice TopK.
+	FetchK int
+	// Embedder, if set, measures similarity as the cosine of embeddings of
+	// the memory texts. By default similarity is the Jaccard index of their
+	// word sets.
+	Embedder Embedder
+}
+
+// WithMMR re-orders and trims search results by maximal marginal relevance
+// so that near-duplicate memories don't crowd out the top k. lambda is
+// clamped to [0, 1]; 0.5 is a reasonable start.
+func WithMMR(lambda float64) SearchOption {
+	return func(r *SearchRequest) {
+		if r.MMR == nil {
+			r.MMR = &MMROptions{}
+		}
+		r.MMR.Lambda = lambda
+	}
+}
+
+// WithMMREmbedder enables MMR, if not already enabled, and measures
+// similarity with e instead of word overlap.
+func WithMMREmbedder(e Embedder) SearchOption {
+	return func(r *SearchRequest) {
+		if r.MMR == nil {
+			r.MMR = &MMROptions{Lambda: 0.5}
+		}
+		r.MMR.Embedder = e
+	}
+}
+
+// fetchK returns the number of candidates to fetch for a final top k.
+func (o *MMROptions) fetchK(topK int) int {
+	if o.FetchK > topK {
+		return o.FetchK
+	}
+	return 2 * topK
+}
+
+// Diversify selects up to k of the memories by maximal marginal
+// relevance, in selection order. Relevance is each memory's Score
+// normalized to the highest score, or its rank if no memory has a score.
+// The input is assumed to be sorted by relevance.
+func Diversify(ctx context.Context, memories []Memory, k int, opts MMROptions) ([]Memory, error) {
+	if k <= 0 || k > len(memories) {
+		k = len(memories)
+	}
+	if len(memories) == 0 {
+		return memories, nil
+	}
+	lambda := min(max(opts.Lambda, 0), 1)
+
+	sim, err := similarity(ctx, memories, opts.Embedder)
+	if err != nil {
+		return nil, err
+	}
+	rel := relevance(memories)
+
+	selected := make([]int, 0, k)
+	used := make([]bool, len(memories))
+	// maxSim[i] is the highest similarity of candidate i to any selected
+	// memory.
+	maxSim := make([]float64, len(memories))
+	for len(selected) < k {
+		best, bestScore := -1, math.Inf(-1)
+		for i := range memories {
+			if used[i] {
+				continue
+			}
+			score := lambda * rel[i]
+			if len(selected) > 0 {
+				score -= (1 - lambda) * maxSim[i]
+			}
+			if score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+		used[best] = true
+		selected = append(selected, best)
+		for i := range memories {
+			if !used[i] {
+				maxSim[i] = max(maxSim[i], sim(i, best))
+			}
+		}
+	}
+
+	out := make([]Memory, len(selected))
+	for i, idx := range selected {
+		out[i] = memories[idx]
+	}
+	return out, nil
+}
+
+func relevance(memories []Memory) []float64 {
+	rel := make([]float64, len(memories))
+	var top float64
+	for _, m := range memories {
+		top = max(top, m.Score)
+	}
+	for i, m := range memories {
+		if top > 0 {
+			rel[i] = m.Score / top
+		} else {
+			rel[i] = 1 - float64(i)/float64(len(memories))
+		}
+	}
+	return rel
+}
+
+// similarity returns a pairwise similarity function over memories.
+func similarity(ctx context.Context, memories []Memory, e Embedder) (func(i, j int) float64, error) {
+	if e == nil {
+		words := make([]map[string]bool, len(memories))
+		for i, m := range memories {
+			words[i] = textsim.WordSet(m.Memory)
+		}
+		return func(i, j int) float64 { return textsim.Jaccard(words[i], words[j]) }, nil
+	}
+
+	vecs := make([][]float32, len(memories))
+	for i, m := range memories {
+		v, err := e.Embed(ctx, m.Memory)
+		if err != nil {
+			return nil, fmt.Errorf("mem0: embed memory %s: %w", m.ID, err)
+		}
+		vecs[i] = v
+	}
+	return func(i, j int) float64 { return textsim.Cosine(vecs[i], vecs[j]) }, nil
+}
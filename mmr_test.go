@@ -0,0 +1,84 @@
+package mem0
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alcova-ai/mem0-go/internal/textsim"
+)
+
+var ramenResults = []Memory{
+	{ID: "1", Memory: "Likes ramen", Score: 0.9},
+	{ID: "2", Memory: "Really likes ramen", Score: 0.88},
+	{ID: "3", Memory: "Allergic to peanuts", Score: 0.5},
+	{ID: "4", Memory: "Likes ramen a lot", Score: 0.45},
+}
+
+func TestDiversify(t *testing.T) {
+	ctx := context.Background()
+
+	got, err := Diversify(ctx, ramenResults, 2, MMROptions{Lambda: 0.5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ids := memoryIDs(got); len(ids) != 2 || ids[0] != "1" || ids[1] != "3" {
+		t.Errorf("expected 1, 3; got %v", ids)
+	}
+
+	got, _ = Diversify(ctx, ramenResults, 3, MMROptions{Lambda: 1})
+	if ids := memoryIDs(got); ids[0] != "1" || ids[1] != "2" || ids[2] != "3" {
+		t.Errorf("expected relevance order with lambda 1, got %v", ids)
+	}
+
+	got, _ = Diversify(ctx, ramenResults, 0, MMROptions{Lambda: 0.5})
+	if len(got) != len(ramenResults) {
+		t.Errorf("expected all results for k 0, got %d", len(got))
+	}
+}
+
+// topicEmbedder maps texts mentioning food to one axis and anything else to
+// another.
+type topicEmbedder struct{}
+
+func (topicEmbedder) Embed(_ context.Context, text string) ([]float32, error) {
+	if words := textsim.WordSet(text); words["ramen"] || words["peanuts"] {
+		return []float32{1, 0}, nil
+	}
+	return []float32{0, 1}, nil
+}
+
+func TestDiversifyEmbedder(t *testing.T) {
+	memories := append(ramenResults[:3:3], Memory{ID: "5", Memory: "Works night shifts", Score: 0.4})
+
+	got, err := Diversify(context.Background(), memories, 2, MMROptions{Lambda: 0.5, Embedder: topicEmbedder{}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ids := memoryIDs(got); ids[0] != "1" || ids[1] != "5" {
+		t.Errorf("expected 1, 5 with embedding similarity, got %v", ids)
+	}
+}
+
+func TestSearchWithMMR(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req SearchRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.TopK != 4 {
+			t.Errorf("expected candidate pool of 4, got top_k %d", req.TopK)
+		}
+		json.NewEncoder(w).Encode(ramenResults)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL))
+	resp, err := client.SearchUserMemories(context.Background(), "u1", "food", WithTopK(2), WithMMR(0.5))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ids := memoryIDs(resp.Results); len(ids) != 2 || ids[0] != "1" || ids[1] != "3" {
+		t.Errorf("expected 1, 3; got %v", ids)
+	}
+}
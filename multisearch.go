@@ -0,0 +1,174 @@
+package mem0
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+)
+
+// FusionStrategy selects how MultiSearch combines per-query rankings.
+type FusionStrategy int
+
+const (
+	// FusionRRF scores each memory by reciprocal rank fusion: the sum over
+	// queries of 1/(k+rank). It ignores raw scores, so it is robust to
+	// queries whose scores are on different scales.
+	FusionRRF FusionStrategy = iota
+	// FusionMaxScore scores each memory by its best score across queries.
+	FusionMaxScore
+	// FusionWeighted scores each memory by the weighted sum of its scores
+	// across queries.
+	FusionWeighted
+)
+
+const (
+	defaultRRFK        = 60
+	defaultParallelism = 4
+)
+
+// MultiSearchOptions controls MultiSearch. The zero value fuses with RRF,
+// running up to four searches at a time.
+type MultiSearchOptions struct {
+	Strategy FusionStrategy
+	// Parallelism bounds the number of concurrent searches. Defaults to 4.
+	Parallelism int
+	// RRFK is the rank offset k for FusionRRF. Defaults to 60.
+	RRFK float64
+	// Weights holds one weight per query for FusionWeighted. If empty every
+	// query has weight 1.
+	Weights []float64
+	// Limit caps the number of fused results. Zero returns all of them.
+	Limit int
+	// SearchOptions are applied to every per-query request, for example
+	// WithTopK or WithThreshold.
+	SearchOptions []SearchOption
+}
+
+// QueryHit records where a memory ranked in one query's results.
+type QueryHit struct {
+	Query      string
+	QueryIndex int
+	Rank       int // 1-based
+	Score      float64
+}
+
+// MultiSearchResponse holds the fused ranking. Each memory's Score is its
+// fused score; Provenance maps memory IDs to the queries that returned
+// them, in query order.
+type MultiSearchResponse struct {
+	Results    []Memory
+	Provenance map[string][]QueryHit
+}
+
+// MultiSearch runs Search for each query concurrently with the same
+// filters, de-duplicates the results by memory ID and fuses the rankings
+// into one. If any search fails the others are cancelled and the first
+// error is returned.
+func (c *Client) MultiSearch(ctx context.Context, queries []string, filters Filters, opts *MultiSearchOptions) (*MultiSearchResponse, error) {
+	if len(queries) == 0 || slices.Contains(queries, "") {
+		return nil, ErrMissingQuery
+	}
+	if filters == nil {
+		return nil, ErrMissingFilters
+	}
+	if opts == nil {
+		opts = &MultiSearchOptions{}
+	}
+	if opts.Strategy == FusionWeighted && len(opts.Weights) > 0 && len(opts.Weights) != len(queries) {
+		return nil, errors.New("mem0: multi-search needs one weight per query")
+	}
+
+	results, err := c.searchAll(ctx, queries, filters, opts)
+	if err != nil {
+		return nil, err
+	}
+	return fuse(queries, results, opts), nil
+}
+
+// searchAll runs one search per query with at most opts.Parallelism in
+// flight and returns the responses in query order.
+func (c *Client) searchAll(ctx context.Context, queries []string, filters Filters, opts *MultiSearchOptions) ([]*SearchResponse, error) {
+	parallelism := opts.Parallelism
+	if parallelism <= 0 {
+		parallelism = defaultParallelism
+	}
+
+	results := make([]*SearchResponse, len(queries))
+	err := forEachLimit(ctx, len(queries), parallelism, func(ctx context.Context, i int) error {
+		// Each request gets its own deep copy of the filters, since
+		// options such as WithSearchFilters modify them in place.
+		req := &SearchRequest{Query: queries[i], Filters: filters.clone()}
+		for _, opt := range opts.SearchOptions {
+			opt(req)
+		}
+		resp, err := c.Search(ctx, req)
+		if err != nil {
+			return err
+		}
+		results[i] = resp
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func fuse(queries []string, results []*SearchResponse, opts *MultiSearchOptions) *MultiSearchResponse {
+	k := opts.RRFK
+	if k <= 0 {
+		k = defaultRRFK
+	}
+
+	out := &MultiSearchResponse{Provenance: make(map[string][]QueryHit)}
+	index := make(map[string]int) // memory ID to position in out.Results
+	for qi, resp := range results {
+		for ri, m := range resp.Results {
+			hit := QueryHit{Query: queries[qi], QueryIndex: qi, Rank: ri + 1, Score: m.Score}
+
+			var contribution float64
+			switch opts.Strategy {
+			case FusionMaxScore:
+				contribution = m.Score
+			case FusionWeighted:
+				w := 1.0
+				if len(opts.Weights) > 0 {
+					w = opts.Weights[qi]
+				}
+				contribution = w * m.Score
+			default:
+				contribution = 1 / (k + float64(hit.Rank))
+			}
+
+			i, seen := index[m.ID]
+			if !seen {
+				i = len(out.Results)
+				index[m.ID] = i
+				m.Score = 0
+				out.Results = append(out.Results, m)
+			}
+			fused := &out.Results[i]
+			if opts.Strategy == FusionMaxScore {
+				if !seen || contribution > fused.Score {
+					fused.Score = contribution
+				}
+			} else {
+				fused.Score += contribution
+			}
+			out.Provenance[m.ID] = append(out.Provenance[m.ID], hit)
+		}
+	}
+
+	// Stable, so ties keep the order in which memories were first seen.
+	slices.SortStableFunc(out.Results, func(a, b Memory) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+	if opts.Limit > 0 && len(out.Results) > opts.Limit {
+		for _, m := range out.Results[opts.Limit:] {
+			delete(out.Provenance, m.ID)
+		}
+		out.Results = out.Results[:opts.Limit]
+	}
+	return out
+}
@@ -0,0 +1,169 @@
+package mem0
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// multiSearchServer answers each query with a fixed ranking and tracks the
+// highest number of concurrent searches.
+func multiSearchServer(t *testing.T, rankings map[string][]Memory) (*Client, *atomic.Int32) {
+	t.Helper()
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		var req SearchRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		ranking, ok := rankings[req.Query]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"detail":"bad query"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(ranking)
+	}))
+	t.Cleanup(server.Close)
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL))
+	return client, &peak
+}
+
+var fusionRankings = map[string][]Memory{
+	"q1": {{ID: "a", Score: 0.9}, {ID: "b", Score: 0.8}, {ID: "c", Score: 0.1}},
+	"q2": {{ID: "b", Score: 0.7}, {ID: "c", Score: 0.6}},
+	"q3": {{ID: "c", Score: 0.5}, {ID: "d", Score: 0.95}},
+}
+
+func memoryIDs(ms []Memory) []string {
+	out := make([]string, len(ms))
+	for i, m := range ms {
+		out[i] = m.ID
+	}
+	return out
+}
+
+func TestMultiSearchRRF(t *testing.T) {
+	client, peak := multiSearchServer(t, fusionRankings)
+
+	resp, err := client.MultiSearch(context.Background(), []string{"q1", "q2", "q3"},
+		NewFilters().WithUserID("u1"), &MultiSearchOptions{Parallelism: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// c: 1/63 + 1/62 + 1/61, b: 1/62 + 1/61, a: 1/61, d: 1/62.
+	if got := memoryIDs(resp.Results); len(got) != 4 || got[0] != "c" || got[1] != "b" || got[2] != "a" || got[3] != "d" {
+		t.Errorf("expected c, b, a, d; got %v", got)
+	}
+	want := 1.0/63 + 1.0/62 + 1.0/61
+	if math.Abs(resp.Results[0].Score-want) > 1e-12 {
+		t.Errorf("expected fused score %v, got %v", want, resp.Results[0].Score)
+	}
+
+	hits := resp.Provenance["c"]
+	if len(hits) != 3 || hits[0].Query != "q1" || hits[0].Rank != 3 || hits[2].Query != "q3" || hits[2].Score != 0.5 {
+		t.Errorf("unexpected provenance for c: %+v", hits)
+	}
+	if p := peak.Load(); p > 2 {
+		t.Errorf("expected at most 2 concurrent searches, got %d", p)
+	}
+}
+
+func TestMultiSearchStrategies(t *testing.T) {
+	client, _ := multiSearchServer(t, fusionRankings)
+	queries := []string{"q1", "q2", "q3"}
+	filters := NewFilters().WithUserID("u1")
+
+	resp, err := client.MultiSearch(context.Background(), queries, filters, &MultiSearchOptions{Strategy: FusionMaxScore})
+	if err != nil {
+		t.Fatalf("max score: unexpected error: %v", err)
+	}
+	if got := memoryIDs(resp.Results); got[0] != "d" || got[1] != "a" || got[2] != "b" || got[3] != "c" {
+		t.Errorf("max score: expected d, a, b, c; got %v", got)
+	}
+
+	resp, err = client.MultiSearch(context.Background(), queries, filters, &MultiSearchOptions{
+		Strategy: FusionWeighted,
+		Weights:  []float64{1, 2, 0},
+		Limit:    2,
+	})
+	if err != nil {
+		t.Fatalf("weighted: unexpected error: %v", err)
+	}
+	// b: 0.8 + 1.4, c: 0.1 + 1.2, a: 0.9, d: 0.
+	if got := memoryIDs(resp.Results); len(got) != 2 || got[0] != "b" || got[1] != "c" {
+		t.Errorf("weighted: expected b, c; got %v", got)
+	}
+	if _, ok := resp.Provenance["a"]; ok {
+		t.Error("weighted: expected provenance to be trimmed with results")
+	}
+
+	_, err = client.MultiSearch(context.Background(), queries, filters, &MultiSearchOptions{
+		Strategy: FusionWeighted,
+		Weights:  []float64{1},
+	})
+	if err == nil {
+		t.Error("expected error for mismatched weights")
+	}
+}
+
+func TestMultiSearchError(t *testing.T) {
+	client, _ := multiSearchServer(t, fusionRankings)
+
+	_, err := client.MultiSearch(context.Background(), []string{"q1", "unknown", "q2"}, NewFilters().WithUserID("u1"), nil)
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 APIError, got %v", err)
+	}
+
+	if _, err := client.MultiSearch(context.Background(), nil, NewFilters(), nil); err != ErrMissingQuery {
+		t.Errorf("expected ErrMissingQuery, got %v", err)
+	}
+}
+
+func TestMultiSearchLogicalFilters(t *testing.T) {
+	want := `{"AND":[{"OR":[{"user_id":"u1"},{"user_id":"u2"}]},{"agent_id":"a1"},{"app_id":"app"},{"OR":[{"run_id":"r1"},{"run_id":"r2"}]}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Filters json.RawMessage `json:"filters"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if string(req.Filters) != want {
+			t.Errorf("expected filters %s, got %s", want, req.Filters)
+		}
+		json.NewEncoder(w).Encode([]Memory{})
+	}))
+	defer server.Close()
+	client, _ := NewClient("test-key", WithBaseURL(server.URL))
+
+	// The AND group has spare capacity, so requests sharing it would race
+	// when WithSearchFilters extends it. Run with -race.
+	filters := NewFilters().WithUserID("u1").
+		Or(NewFilters().WithUserID("u2")).
+		WithAgentID("a1").
+		WithAppID("app")
+	runs := NewFilters().WithRunID("r1").Or(NewFilters().WithRunID("r2"))
+	queries := []string{"q1", "q2", "q3", "q4", "q5", "q6", "q7", "q8"}
+	_, err := client.MultiSearch(context.Background(), queries, filters, &MultiSearchOptions{
+		Parallelism:   len(queries),
+		SearchOptions: []SearchOption{WithSearchFilters(runs)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -1,8 +1,12 @@
 package mem0
 
 import (
+	"log/slog"
 	"net/http"
 	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type ClientOption func(*Client)
@@ -42,3 +46,102 @@ func WithProjectID(projectID string) ClientOption {
 		c.projectID = projectID
 	}
 }
+
+// WithSearchCache caches up to size search responses for ttl, keyed on the
+// normalized request including filters. Cached searches are invalidated
+// when AddMemories, UpdateMemory, DeleteMemory, DeleteMemories,
+// BatchUpdate, BatchDelete or DeleteEntity touch their user or agent
+// scope. Writes made by other clients are only seen once entries expire.
+// A ttl of zero or less means entries never expire.
+func WithSearchCache(size int, ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.cache = newSearchCache(size, ttl)
+	}
+}
+
+// WithBatchSize sets the maximum number of items BatchUpdate and
+// BatchDelete send per request. Defaults to 1000, the platform's limit.
+func WithBatchSize(n int) ClientOption {
+	return func(c *Client) {
+		c.batchSize = n
+	}
+}
+
+// WithBatchConcurrency sets how many batch chunks are sent at once.
+// Defaults to 4.
+func WithBatchConcurrency(n int) ClientOption {
+	return func(c *Client) {
+		c.batchConcurrency = n
+	}
+}
+
+// WithRetryPolicy enables automatic retries of failed requests.
+func WithRetryPolicy(p RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retry = &p
+	}
+}
+
+// WithRateLimit limits the client to rps requests per second with bursts of
+// up to burst requests. Retries also draw from the budget.
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return func(c *Client) {
+		c.limiter = NewRateLimiter(rps, burst)
+	}
+}
+
+// WithRateLimiter installs a limiter that may be shared with other clients.
+func WithRateLimiter(l *RateLimiter) ClientOption {
+	return func(c *Client) {
+		c.limiter = l
+	}
+}
+
+// WithMiddleware appends middleware to the client's chain. Middleware runs
+// in the order given, the first being outermost, once per attempt.
+func WithMiddleware(mw ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, mw...)
+	}
+}
+
+// WithTracerProvider enables tracing. Each client operation, such as Search
+// or AddMemories, is recorded as one span covering all of its attempts.
+func WithTracerProvider(tp trace.TracerProvider) ClientOption {
+	return func(c *Client) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider enables metrics: an operation duration histogram and an
+// error counter keyed by APIError code.
+func WithMeterProvider(mp metric.MeterProvider) ClientOption {
+	return func(c *Client) {
+		c.meterProvider = mp
+	}
+}
+
+// WithLogger enables structured logging of requests. The Authorization
+// header is always redacted, and message content and memory text are
+// redacted unless WithLogBodies is set.
+func WithLogger(l *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = l
+	}
+}
+
+// WithLogLevel sets the level at which successful requests are logged.
+// Failed attempts are logged at Warn or above. The default is Debug.
+func WithLogLevel(level slog.Level) ClientOption {
+	return func(c *Client) {
+		c.logLevel = level
+	}
+}
+
+// WithLogBodies disables redaction of request and response bodies. Only use
+// it for local debugging.
+func WithLogBodies(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.logBodies = enabled
+	}
+}
@@ -0,0 +1,160 @@
+package mem0
+
+import (
+	"context"
+	"iter"
+)
+
+const defaultPageSize = 100
+
+type pageConfig struct {
+	pageSize int
+	prefetch bool
+	maxItems int
+}
+
+// PageOption configures the AllMemories and AllEntities iterators.
+type PageOption func(*pageConfig)
+
+// WithPageSize sets the number of items requested per page. The default is
+// 100.
+func WithPageSize(n int) PageOption {
+	return func(c *pageConfig) { c.pageSize = n }
+}
+
+// WithPrefetch fetches the next page in the background while the current
+// one is being consumed.
+func WithPrefetch(enabled bool) PageOption {
+	return func(c *pageConfig) { c.prefetch = enabled }
+}
+
+// WithMaxItems stops iteration after n items.
+func WithMaxItems(n int) PageOption {
+	return func(c *pageConfig) { c.maxItems = n }
+}
+
+// AllMemories returns an iterator over every memory matching filters,
+// fetching pages lazily as the loop advances. Iteration stops at the first
+// error, which is yielded with a zero Memory.
+//
+//	for m, err := range client.AllMemories(ctx, mem0.NewFilters().WithUserID("u1")) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(m.Memory)
+//	}
+func (c *Client) AllMemories(ctx context.Context, filters Filters, opts ...PageOption) iter.Seq2[Memory, error] {
+	return paginate(ctx, opts, func(ctx context.Context, page, size int) ([]Memory, bool, error) {
+		resp, err := c.GetMemories(ctx, &GetMemoriesRequest{
+			Filters:  filters,
+			Page:     page,
+			PageSize: size,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		return resp.Results, resp.hasMore(), nil
+	})
+}
+
+// AllEntities returns an iterator over every entity of the given type, or of
+// all types if entityType is empty.
+func (c *Client) AllEntities(ctx context.Context, entityType EntityType, opts ...PageOption) iter.Seq2[Entity, error] {
+	return paginate(ctx, opts, func(ctx context.Context, page, size int) ([]Entity, bool, error) {
+		resp, err := c.ListEntities(ctx, &ListEntitiesRequest{
+			Type:     entityType,
+			Page:     page,
+			PageSize: size,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		return resp.Results, resp.hasMore(), nil
+	})
+}
+
+// hasMorePages decides whether to request another page. Unpaginated
+// responses already hold every result.
+func hasMorePages(paginated bool, next string, page, pageSize, total, n int) bool {
+	switch {
+	case !paginated || n == 0:
+		return false
+	case next != "":
+		return true
+	case total > 0 && page > 0 && pageSize > 0:
+		return page*pageSize < total
+	}
+	return pageSize > 0 && n >= pageSize
+}
+
+type pageFetcher[T any] func(ctx context.Context, page, size int) (items []T, more bool, err error)
+
+type pageResult[T any] struct {
+	items []T
+	more  bool
+	err   error
+}
+
+func paginate[T any](ctx context.Context, opts []PageOption, fetch pageFetcher[T]) iter.Seq2[T, error] {
+	cfg := pageConfig{pageSize: defaultPageSize}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.pageSize <= 0 {
+		cfg.pageSize = defaultPageSize
+	}
+
+	return func(yield func(T, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		get := func(page int) <-chan pageResult[T] {
+			ch := make(chan pageResult[T], 1)
+			go func() {
+				items, more, err := fetch(ctx, page, cfg.pageSize)
+				ch <- pageResult[T]{items, more, err}
+			}()
+			return ch
+		}
+
+		var zero T
+		yielded := 0
+		pending := get(1)
+		for page := 1; ; page++ {
+			res := <-pending
+			if res.err != nil {
+				yield(zero, res.err)
+				return
+			}
+
+			more := res.more
+			if cfg.maxItems > 0 && yielded+len(res.items) >= cfg.maxItems {
+				more = false
+			}
+			if more {
+				if cfg.prefetch {
+					pending = get(page + 1)
+				} else {
+					pending = nil
+				}
+			}
+
+			for _, item := range res.items {
+				if !yield(item, nil) {
+					return
+				}
+				yielded++
+				if cfg.maxItems > 0 && yielded >= cfg.maxItems {
+					return
+				}
+			}
+
+			if !more {
+				return
+			}
+			if pending == nil {
+				pending = get(page + 1)
+			}
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package mem0
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func newPagedMemoryServer(t *testing.T, total int, requests *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/v2/memories/" {
+			t.Errorf("expected /v2/memories/, got %s", r.URL.Path)
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		size, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+
+		var results []Memory
+		for i := (page - 1) * size; i < page*size && i < total; i++ {
+			results = append(results, Memory{ID: fmt.Sprintf("mem-%d", i)})
+		}
+		var next *string
+		if page*size < total {
+			u := fmt.Sprintf("/v2/memories/?page=%d&page_size=%d", page+1, size)
+			next = &u
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"count":   total,
+			"next":    next,
+			"results": results,
+		})
+	}))
+}
+
+func TestGetMemoriesPaginated(t *testing.T) {
+	var requests atomic.Int32
+	server := newPagedMemoryServer(t, 5, &requests)
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL))
+
+	resp, err := client.GetMemories(context.Background(), &GetMemoriesRequest{
+		Filters:  NewFilters().WithUserID("user-1"),
+		Page:     2,
+		PageSize: 2,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Total != 5 || resp.Page != 2 || resp.PageSize != 2 {
+		t.Errorf("expected total 5, page 2, size 2; got %d, %d, %d", resp.Total, resp.Page, resp.PageSize)
+	}
+	if len(resp.Results) != 2 || resp.Results[0].ID != "mem-2" {
+		t.Errorf("unexpected results: %+v", resp.Results)
+	}
+}
+
+func TestAllMemories(t *testing.T) {
+	for _, prefetch := range []bool{false, true} {
+		t.Run(fmt.Sprintf("prefetch=%v", prefetch), func(t *testing.T) {
+			var requests atomic.Int32
+			server := newPagedMemoryServer(t, 7, &requests)
+			defer server.Close()
+
+			client, _ := NewClient("test-key", WithBaseURL(server.URL))
+
+			var ids []string
+			for m, err := range client.AllMemories(context.Background(), NewFilters().WithUserID("user-1"),
+				WithPageSize(3), WithPrefetch(prefetch)) {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				ids = append(ids, m.ID)
+			}
+
+			if len(ids) != 7 || ids[6] != "mem-6" {
+				t.Errorf("expected 7 memories ending in mem-6, got %v", ids)
+			}
+			if requests.Load() != 3 {
+				t.Errorf("expected 3 requests, got %d", requests.Load())
+			}
+		})
+	}
+}
+
+func TestAllMemoriesMaxItems(t *testing.T) {
+	var requests atomic.Int32
+	server := newPagedMemoryServer(t, 100, &requests)
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL))
+
+	n := 0
+	for _, err := range client.AllMemories(context.Background(), NewFilters().WithUserID("user-1"),
+		WithPageSize(4), WithMaxItems(6)) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		n++
+	}
+	if n != 6 {
+		t.Errorf("expected 6 memories, got %d", n)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("expected 2 requests, got %d", requests.Load())
+	}
+}
+
+func TestAllMemoriesUnpaginated(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]Memory{{ID: "mem-1"}, {ID: "mem-2"}})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL))
+
+	n := 0
+	for _, err := range client.AllMemories(context.Background(), NewFilters().WithUserID("user-1"), WithPageSize(2)) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		n++
+	}
+	if n != 2 {
+		t.Errorf("expected 2 memories from a bare array response, got %d", n)
+	}
+}
+
+func TestAllEntitiesError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"count":   4,
+			"results": []Entity{{ID: "u1"}, {ID: "u2"}},
+		})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL))
+
+	var ids []string
+	var gotErr error
+	for e, err := range client.AllEntities(context.Background(), EntityTypeUser, WithPageSize(2)) {
+		if err != nil {
+			gotErr = err
+			break
+		}
+		ids = append(ids, e.ID)
+	}
+	if len(ids) != 2 {
+		t.Errorf("expected 2 entities before the error, got %v", ids)
+	}
+	if apiErr, ok := gotErr.(*APIError); !ok || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("expected 500 APIError, got %v", gotErr)
+	}
+}
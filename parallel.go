@@ -0,0 +1,63 @@
+package mem0
+
+import (
+	"context"
+	"sync"
+)
+
+// eachLimit calls fn for every index in [0, n) with at most limit calls in
+// flight and returns each call's error. A failed call does not stop the
+// others; calls still waiting for a slot when ctx is done are skipped and
+// get ctx's error.
+func eachLimit(ctx context.Context, n, limit int, fn func(ctx context.Context, i int) error) []error {
+	errs := make([]error, n)
+	sem := make(chan struct{}, max(limit, 1))
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+			errs[i] = fn(ctx, i)
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// forEachLimit is eachLimit for all-or-nothing work: if a call fails, the
+// context passed to the others is cancelled and the first error is
+// returned once every call has finished.
+func forEachLimit(ctx context.Context, n, limit int, fn func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		once     sync.Once
+		firstErr error
+	)
+	errs := eachLimit(ctx, n, limit, func(ctx context.Context, i int) error {
+		err := fn(ctx, i)
+		if err != nil {
+			once.Do(func() { firstErr = err })
+			cancel()
+		}
+		return err
+	})
+	if firstErr != nil {
+		return firstErr
+	}
+	// No call failed, but ctx may have ended before some started.
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
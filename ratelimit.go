@@ -0,0 +1,149 @@
+package mem0
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a client-side token bucket limiter. It is safe for
+// concurrent use and may be shared by several clients using the same API key
+// via WithRateLimiter.
+//
+// Requests draw from a default budget unless their path matches an endpoint
+// budget registered with SetEndpointLimit, in which case they draw only from
+// the budget with the longest matching prefix.
+type RateLimiter struct {
+	mu        sync.RWMutex
+	def       *tokenBucket
+	endpoints []endpointBucket
+}
+
+type endpointBucket struct {
+	prefix string
+	bucket *tokenBucket
+}
+
+// NewRateLimiter returns a limiter allowing rps requests per second on
+// average with bursts of up to burst requests. A non-positive rps leaves
+// requests outside any endpoint budget unlimited.
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	return &RateLimiter{def: newTokenBucket(rps, burst)}
+}
+
+// SetEndpointLimit gives requests whose path starts with prefix, such as
+// "/v2/memories/search/", their own budget.
+func (l *RateLimiter) SetEndpointLimit(prefix string, rps float64, burst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i, e := range l.endpoints {
+		if e.prefix == prefix {
+			l.endpoints[i].bucket = newTokenBucket(rps, burst)
+			return
+		}
+	}
+	l.endpoints = append(l.endpoints, endpointBucket{prefix: prefix, bucket: newTokenBucket(rps, burst)})
+	sort.Slice(l.endpoints, func(i, j int) bool {
+		return len(l.endpoints[i].prefix) > len(l.endpoints[j].prefix)
+	})
+}
+
+// Wait blocks until a request to path is allowed. It returns ctx.Err() if
+// ctx is cancelled first, or context.DeadlineExceeded without waiting if the
+// deadline would pass before a token becomes available.
+func (l *RateLimiter) Wait(ctx context.Context, path string) error {
+	l.mu.RLock()
+	b := l.def
+	for _, e := range l.endpoints {
+		if strings.HasPrefix(path, e.prefix) {
+			b = e.bucket
+			break
+		}
+	}
+	l.mu.RUnlock()
+
+	if b == nil {
+		return ctx.Err()
+	}
+	return b.wait(ctx)
+}
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rps float64, burst int) *tokenBucket {
+	if rps <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token, letting the balance go negative, and returns how
+// long the caller must wait before using it.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token that will not be used.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	b.tokens++
+	b.mu.Unlock()
+}
+
+func (b *tokenBucket) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	d := b.reserve(now)
+	if d == 0 {
+		return nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(now.Add(d)) {
+		b.cancel()
+		return context.DeadlineExceeded
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,80 @@
+package mem0
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	l := NewRateLimiter(1, 3)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(ctx, "/v1/memories/"); err != nil {
+			t.Fatalf("request %d: unexpected error: %v", i, err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx, "/v1/memories/"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded once burst is spent, got %v", err)
+	}
+}
+
+func TestRateLimiterEndpointBudgets(t *testing.T) {
+	l := NewRateLimiter(1, 1)
+	l.SetEndpointLimit("/v2/memories/search/", 1, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if err := l.Wait(ctx, "/v2/memories/search/"); err != nil {
+		t.Fatalf("search: unexpected error: %v", err)
+	}
+	if err := l.Wait(ctx, "/v1/memories/"); err != nil {
+		t.Fatalf("add: expected separate budget, got %v", err)
+	}
+	if err := l.Wait(ctx, "/v2/memories/search/"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("search: expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestRateLimiterConcurrent(t *testing.T) {
+	l := NewRateLimiter(200, 1)
+	ctx := context.Background()
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := l.Wait(ctx, "/v1/memories/"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 20 requests at 200/s with a burst of 1 take at least ~95ms.
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("expected requests to be spaced out, finished in %v", elapsed)
+	}
+}
+
+func TestRateLimitCancelledWait(t *testing.T) {
+	client, _ := NewClient("test-key", WithRateLimit(0.001, 1))
+	client.limiter.Wait(context.Background(), "/v1/memories/")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.GetMemory(ctx, "mem-1")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
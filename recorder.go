@@ -0,0 +1,184 @@
+package mem0
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sync"
+	"time"
+)
+
+var ErrRecorderClosed = errors.New("mem0: recorder is closed")
+
+const defaultFlushTurns = 10
+
+// RecorderOptions configures a ConversationRecorder. At least one of
+// UserID, AgentID or RunID must be set.
+type RecorderOptions struct {
+	UserID  string
+	AgentID string
+	AppID   string
+	RunID   string
+
+	// FlushTurns is the number of turns buffered before they are flushed.
+	// Defaults to 10.
+	FlushTurns int
+	// IdleTimeout flushes buffered turns when no turn has been recorded for
+	// this long. Zero disables idle flushing.
+	IdleTimeout time.Duration
+	// Metadata is attached to every flush, for example a conversation_id.
+	Metadata map[string]any
+	// AddOptions are applied to every flushed request, for example
+	// WithInfer(false).
+	AddOptions []AddMemoryOption
+
+	// Retry controls how failed flushes are retried. Nil uses
+	// DefaultRetryPolicy. Retried adds may create duplicate memories if the
+	// failed attempt reached the server.
+	Retry *RetryPolicy
+	// DeadLetter is called with a batch that still failed after retries.
+	// Flushes triggered by Record, Flush or Close also return the error;
+	// for idle flushes DeadLetter is the only way to learn of it.
+	DeadLetter func(req *AddMemoriesRequest, err error)
+}
+
+// ConversationRecorder buffers the messages of a live conversation and
+// sends them to AddMemories in batches: every FlushTurns turns, after
+// IdleTimeout without a turn, and on Close. Batches are sent one at a time
+// in the order they were recorded. It is safe for concurrent use.
+type ConversationRecorder struct {
+	store MemoryStore
+	opts  RecorderOptions
+	retry RetryPolicy
+
+	// flushMu serializes flushes so batches reach the store in order.
+	flushMu sync.Mutex
+
+	mu       sync.Mutex
+	buf      []Message
+	turns    int
+	metadata map[string]any
+	timer    *time.Timer
+	closed   bool
+}
+
+// NewConversationRecorder returns a recorder that adds memories to store in
+// the scope given by opts.
+func NewConversationRecorder(store MemoryStore, opts RecorderOptions) (*ConversationRecorder, error) {
+	if opts.UserID == "" && opts.AgentID == "" && opts.RunID == "" {
+		return nil, errors.New("mem0: recorder needs a user, agent or run ID")
+	}
+	if opts.FlushTurns <= 0 {
+		opts.FlushTurns = defaultFlushTurns
+	}
+	r := &ConversationRecorder{
+		store:    store,
+		opts:     opts,
+		retry:    DefaultRetryPolicy(),
+		metadata: maps.Clone(opts.Metadata),
+	}
+	if opts.Retry != nil {
+		r.retry = *opts.Retry
+	}
+	if opts.IdleTimeout > 0 {
+		r.timer = time.AfterFunc(opts.IdleTimeout, func() {
+			r.Flush(context.Background())
+		})
+		r.timer.Stop()
+	}
+	return r, nil
+}
+
+// Record buffers one turn, typically a user message and the assistant's
+// reply. If the turn fills the buffer it is flushed before Record returns.
+func (r *ConversationRecorder) Record(ctx context.Context, msgs ...Message) error {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return ErrRecorderClosed
+	}
+	if len(msgs) == 0 {
+		r.mu.Unlock()
+		return nil
+	}
+	r.buf = append(r.buf, msgs...)
+	r.turns++
+	full := r.turns >= r.opts.FlushTurns
+	if r.timer != nil {
+		r.timer.Reset(r.opts.IdleTimeout)
+	}
+	r.mu.Unlock()
+
+	if full {
+		return r.Flush(ctx)
+	}
+	return nil
+}
+
+// SetMetadata sets a metadata key on subsequent flushes.
+func (r *ConversationRecorder) SetMetadata(key string, value any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.metadata == nil {
+		r.metadata = make(map[string]any)
+	}
+	r.metadata[key] = value
+}
+
+// Flush sends any buffered turns now.
+func (r *ConversationRecorder) Flush(ctx context.Context) error {
+	r.flushMu.Lock()
+	defer r.flushMu.Unlock()
+
+	r.mu.Lock()
+	if len(r.buf) == 0 {
+		r.mu.Unlock()
+		return nil
+	}
+	req := &AddMemoriesRequest{
+		Messages: r.buf,
+		UserID:   r.opts.UserID,
+		AgentID:  r.opts.AgentID,
+		AppID:    r.opts.AppID,
+		RunID:    r.opts.RunID,
+	}
+	for _, opt := range r.opts.AddOptions {
+		opt(req)
+	}
+	if len(r.metadata) > 0 {
+		md := maps.Clone(req.Metadata)
+		if md == nil {
+			md = make(map[string]any, len(r.metadata))
+		}
+		maps.Copy(md, r.metadata)
+		req.Metadata = md
+	}
+	r.buf, r.turns = nil, 0
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+	r.mu.Unlock()
+
+	err := addWithRetry(ctx, r.store, req, r.retry, true)
+	if err != nil && r.opts.DeadLetter != nil {
+		r.opts.DeadLetter(req, err)
+	}
+	return err
+}
+
+// Close flushes any buffered turns and stops the recorder. Later calls to
+// Record return ErrRecorderClosed.
+func (r *ConversationRecorder) Close(ctx context.Context) error {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil
+	}
+	r.closed = true
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+	r.mu.Unlock()
+
+	return r.Flush(ctx)
+}
@@ -0,0 +1,139 @@
+package mem0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// addRecorder is a MemoryStore that records AddMemories calls, failing the
+// next failures of them with a 503.
+type addRecorder struct {
+	MemoryStore
+
+	mu       sync.Mutex
+	failures int
+	reqs     []AddMemoriesRequest
+	added    chan struct{}
+}
+
+func (s *addRecorder) AddMemories(_ context.Context, req *AddMemoriesRequest) (*AddMemoriesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reqs = append(s.reqs, *req)
+	if s.failures > 0 {
+		s.failures--
+		return nil, &APIError{StatusCode: http.StatusServiceUnavailable}
+	}
+	if s.added != nil {
+		s.added <- struct{}{}
+	}
+	return &AddMemoriesResponse{}, nil
+}
+
+func userTurn(text string) []Message {
+	return []Message{{Role: "user", Content: text}, {Role: "assistant", Content: "noted"}}
+}
+
+func TestRecorderFlushesEveryNTurns(t *testing.T) {
+	store := &addRecorder{}
+	rec, err := NewConversationRecorder(store, RecorderOptions{
+		UserID:     "u1",
+		RunID:      "r1",
+		FlushTurns: 2,
+		Metadata:   map[string]any{"conversation_id": "c1"},
+		AddOptions: []AddMemoryOption{WithInfer(false)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx := context.Background()
+
+	rec.Record(ctx, userTurn("I moved to Lisbon")...)
+	if len(store.reqs) != 0 {
+		t.Fatalf("expected no flush after one turn, got %d", len(store.reqs))
+	}
+	rec.SetMetadata("topic", "travel")
+	if err := rec.Record(ctx, userTurn("I'm vegetarian")...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(store.reqs) != 1 {
+		t.Fatalf("expected one flush after two turns, got %d", len(store.reqs))
+	}
+
+	req := store.reqs[0]
+	if len(req.Messages) != 4 || req.UserID != "u1" || req.RunID != "r1" {
+		t.Errorf("expected 4 messages for u1/r1, got %+v", req)
+	}
+	if req.Metadata["conversation_id"] != "c1" || req.Metadata["topic"] != "travel" {
+		t.Errorf("expected flush metadata, got %v", req.Metadata)
+	}
+	if req.Infer == nil || *req.Infer {
+		t.Error("expected add options to be applied")
+	}
+
+	rec.Record(ctx, userTurn("I have a cat")...)
+	if err := rec.Close(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(store.reqs) != 2 || len(store.reqs[1].Messages) != 2 {
+		t.Errorf("expected Close to flush the remaining turn, got %d flushes", len(store.reqs))
+	}
+	if err := rec.Record(ctx, userTurn("too late")...); err != ErrRecorderClosed {
+		t.Errorf("expected ErrRecorderClosed, got %v", err)
+	}
+}
+
+func TestRecorderIdleFlush(t *testing.T) {
+	store := &addRecorder{added: make(chan struct{}, 1)}
+	rec, _ := NewConversationRecorder(store, RecorderOptions{AgentID: "a1", IdleTimeout: 20 * time.Millisecond})
+	defer rec.Close(context.Background())
+
+	rec.Record(context.Background(), userTurn("Call me Sam")...)
+	select {
+	case <-store.added:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected idle flush")
+	}
+}
+
+func TestRecorderRetryAndDeadLetter(t *testing.T) {
+	store := &addRecorder{failures: 1}
+	var dead []*AddMemoriesRequest
+	rec, _ := NewConversationRecorder(store, RecorderOptions{
+		UserID:     "u1",
+		FlushTurns: 1,
+		Retry:      &RetryPolicy{MaxRetries: 2},
+		DeadLetter: func(req *AddMemoriesRequest, err error) { dead = append(dead, req) },
+	})
+	ctx := context.Background()
+
+	if err := rec.Record(ctx, userTurn("first")...); err != nil {
+		t.Fatalf("expected retry to succeed, got %v", err)
+	}
+	if len(store.reqs) != 2 || len(dead) != 0 {
+		t.Errorf("expected 2 attempts and no dead letters, got %d and %d", len(store.reqs), len(dead))
+	}
+
+	store.failures = 3
+	err := rec.Record(ctx, userTurn("second")...)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 APIError, got %v", err)
+	}
+	if len(store.reqs) != 5 {
+		t.Errorf("expected 3 more attempts, got %d", len(store.reqs)-2)
+	}
+	if len(dead) != 1 || dead[0].Messages[0].Content != "second" {
+		t.Errorf("expected failed batch in dead letter, got %+v", dead)
+	}
+}
+
+func TestRecorderRequiresScope(t *testing.T) {
+	if _, err := NewConversationRecorder(&addRecorder{}, RecorderOptions{AppID: "app"}); err == nil {
+		t.Error("expected error without user, agent or run ID")
+	}
+}
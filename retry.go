@@ -0,0 +1,158 @@
+package mem0
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how the client retries failed requests.
+//
+// GET, PUT and DELETE requests are retried when the policy is installed.
+// POST requests (AddMemories, Search, GetMemories) are only retried when
+// RetryPOST is set, since retrying an add may create duplicate memories.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the initial attempt.
+	MaxRetries int
+	// InitialBackoff is the base delay before the first retry. It doubles
+	// on every subsequent attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the computed delay between attempts. It does not cap
+	// a server-provided Retry-After.
+	MaxBackoff time.Duration
+	// RetryPOST enables retries for POST requests.
+	RetryPOST bool
+	// ShouldRetry reports whether err is worth retrying. When nil,
+	// transport errors and errors for which APIError.IsRetryable reports
+	// true are retried.
+	ShouldRetry func(err error) bool
+}
+
+// DefaultRetryPolicy returns a policy with three retries and backoff
+// starting at 500ms, capped at 10s.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+	}
+}
+
+func (p *RetryPolicy) allows(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return p.RetryPOST
+	}
+	return false
+}
+
+func (p *RetryPolicy) retryable(err error) bool {
+	if p.ShouldRetry != nil {
+		return p.ShouldRetry(err)
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.IsRetryable()
+	}
+	return true
+}
+
+// backoff returns the delay before retry number attempt (zero-based). A
+// Retry-After sent with a 429 or 503 response takes precedence over the
+// computed exponential delay.
+func (p *RetryPolicy) backoff(attempt int, err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 &&
+		(apiErr.IsRateLimited() || apiErr.StatusCode == http.StatusServiceUnavailable) {
+		return apiErr.RetryAfter
+	}
+
+	d := p.InitialBackoff
+	if d <= 0 {
+		return 0
+	}
+	for i := 0; i < attempt; i++ {
+		d *= 2
+		if p.MaxBackoff > 0 && d >= p.MaxBackoff {
+			d = p.MaxBackoff
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+
+	// Equal jitter: keep half the delay and randomise the other half so
+	// concurrent clients do not retry in lockstep.
+	half := d / 2
+	return half + rand.N(half+1)
+}
+
+// addWithRetry adds req to store, retrying failures that policy deems
+// retryable. If limited is set it gives up after policy.MaxRetries retries;
+// otherwise it retries until ctx is done.
+func addWithRetry(ctx context.Context, store MemoryStore, req *AddMemoriesRequest, policy RetryPolicy, limited bool) error {
+	for attempt := 0; ; attempt++ {
+		// AddMemories may fill in defaults, so each attempt sends a copy.
+		attemptReq := *req
+		_, err := store.AddMemories(ctx, &attemptReq)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+		if limited && attempt >= policy.MaxRetries || !policy.retryable(err) {
+			return err
+		}
+		if !sleepCtx(ctx, policy.backoff(min(attempt, 30), err)) {
+			return err
+		}
+	}
+}
+
+// sleepCtx waits for d or until ctx is done. It returns false without
+// waiting when ctx would expire before d elapses.
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return false
+	}
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+// parseRetryAfter parses a Retry-After header given either as a number of
+// seconds or as an HTTP date.
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
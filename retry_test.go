@@ -0,0 +1,145 @@
+package mem0
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryOnServerError(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		json.NewEncoder(w).Encode(Memory{ID: "mem-1"})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond}),
+	)
+
+	mem, err := client.GetMemory(context.Background(), "mem-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mem.ID != "mem-1" {
+		t.Errorf("expected ID 'mem-1', got %q", mem.ID)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestRetryReplaysBody(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req SearchRequest
+		if err := json.Unmarshal(body, &req); err != nil || req.Query != "q" {
+			t.Errorf("attempt %d: unexpected body %q", calls.Load()+1, body)
+		}
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		json.NewEncoder(w).Encode([]Memory{{ID: "mem-1"}})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 1, InitialBackoff: time.Millisecond, RetryPOST: true}),
+	)
+
+	resp, err := client.SearchUserMemories(context.Background(), "user-1", "q")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Results) != 1 {
+		t.Errorf("expected 1 result, got %d", len(resp.Results))
+	}
+}
+
+func TestRetrySkipsPOSTByDefault(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond}),
+	)
+
+	_, err := client.AddMemory(context.Background(), "content", WithUserID("user-1"))
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 attempt, got %d", calls.Load())
+	}
+}
+
+func TestRetryNotFoundIsFinal(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond}),
+	)
+
+	_, err := client.GetMemory(context.Background(), "mem-1")
+	apiErr, ok := err.(*APIError)
+	if !ok || !apiErr.IsNotFound() {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 attempt, got %d", calls.Load())
+	}
+}
+
+func TestRetryRespectsDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithRetryPolicy(DefaultRetryPolicy()),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetMemory(ctx, "mem-1")
+	if time.Since(start) > 500*time.Millisecond {
+		t.Errorf("expected to give up before sleeping past the deadline")
+	}
+	apiErr, ok := err.(*APIError)
+	if !ok || !apiErr.IsRateLimited() {
+		t.Fatalf("expected rate limited error, got %v", err)
+	}
+	if apiErr.RetryAfter != 30*time.Second {
+		t.Errorf("expected RetryAfter 30s, got %v", apiErr.RetryAfter)
+	}
+}
@@ -1,7 +1,9 @@
 package mem0
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
 )
 
@@ -17,12 +19,27 @@ type SearchRequest struct {
 	Fields         []string `json:"fields,omitempty"`
 	OrgID          string   `json:"org_id,omitempty"`
 	ProjectID      string   `json:"project_id,omitempty"`
+
+	// MMR, if set, diversifies the results client-side. See WithMMR.
+	MMR *MMROptions `json:"-"`
 }
 
 type SearchResponse struct {
 	Results []Memory `json:"results"`
 }
 
+// UnmarshalJSON accepts both the bare array returned by the search endpoint
+// and an object with a "results" field.
+func (r *SearchResponse) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		return json.Unmarshal(data, &r.Results)
+	}
+
+	type plain SearchResponse
+	return json.Unmarshal(data, (*plain)(r))
+}
+
 // Search performs a semantic search across memories using the given query and filters.
 func (c *Client) Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
 	if req == nil || req.Query == "" {
@@ -31,6 +48,9 @@ func (c *Client) Search(ctx context.Context, req *SearchRequest) (*SearchRespons
 	if req.Filters == nil {
 		return nil, ErrMissingFilters
 	}
+	if err := validateFilters(req.Filters); err != nil {
+		return nil, err
+	}
 
 	if req.Version == "" {
 		req.Version = "v2"
@@ -42,14 +62,56 @@ func (c *Client) Search(ctx context.Context, req *SearchRequest) (*SearchRespons
 		req.ProjectID = c.projectID
 	}
 
-	var results []Memory
-	if err := c.do(ctx, http.MethodPost, "/v2/memories/search/", nil, req, &results); err != nil {
+	if req.MMR != nil {
+		return c.searchMMR(ctx, req)
+	}
+
+	var key string
+	var gen uint64
+	if c.cache != nil {
+		key = searchCacheKey(req)
+		cached, g, ok := c.cache.get(key)
+		if ok {
+			return cached, nil
+		}
+		gen = g
+	}
+
+	var resp SearchResponse
+	if err := c.do(ctx, "Search", http.MethodPost, "/v2/memories/search/", nil, req, &resp); err != nil {
 		return nil, err
 	}
 
-	resp := &SearchResponse{Results: results}
+	if c.cache != nil {
+		c.cache.put(key, gen, req, &resp)
+	}
+	return &resp, nil
+}
+
+// defaultSearchTopK is the number of results the platform returns when a
+// search sets no top_k.
+const defaultSearchTopK = 10
+
+// searchMMR fetches a larger candidate pool and diversifies it down to the
+// requested top k.
+func (c *Client) searchMMR(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
+	topK := req.TopK
+	if topK <= 0 {
+		topK = defaultSearchTopK
+	}
+	pool := *req
+	pool.MMR = nil
+	pool.TopK = req.MMR.fetchK(topK)
 
-	return resp, nil
+	resp, err := c.Search(ctx, &pool)
+	if err != nil {
+		return nil, err
+	}
+	results, err := Diversify(ctx, resp.Results, topK, *req.MMR)
+	if err != nil {
+		return nil, err
+	}
+	return &SearchResponse{Results: results}, nil
 }
 
 // SearchUserMemories is a convenience method to search memories for a specific user.
@@ -95,6 +157,15 @@ func WithSearchFilters(filters Filters) SearchOption {
 		if r.Filters == nil {
 			r.Filters = NewFilters()
 		}
+		// Merging keys into or next to a logical group would change its
+		// meaning, so AND the two instead.
+		// filters is copied so that requests built from the same option
+		// never share nested groups or ranges.
+		filters := filters.clone()
+		if len(r.Filters) > 0 && (r.Filters.logical() || filters.logical()) {
+			r.Filters.andWith(filters)
+			return
+		}
 		for k, v := range filters {
 			r.Filters[k] = v
 		}
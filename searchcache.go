@@ -0,0 +1,293 @@
+package mem0
+
+import (
+	"container/list"
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SearchCacheStats reports the activity of a client's search cache.
+type SearchCacheStats struct {
+	Hits          uint64
+	Misses        uint64
+	Evictions     uint64 // entries dropped for capacity or expiry
+	Invalidations uint64 // entries dropped because a write touched their scope
+	Entries       int
+}
+
+// cacheScope identifies the user and agent a search or write is confined
+// to. Empty fields are unconstrained.
+type cacheScope struct {
+	userID  string
+	agentID string
+}
+
+// affects reports whether a write in scope w may change the results of a
+// search in scope s. A search constrained to a user or agent can only see
+// memories carrying that user or agent, so a write carrying a different
+// one cannot affect it; unconstrained searches are affected by any write.
+func (s cacheScope) affects(w cacheScope) bool {
+	if s.userID == "" && s.agentID == "" {
+		return true
+	}
+	return s.userID != "" && s.userID == w.userID || s.agentID != "" && s.agentID == w.agentID
+}
+
+type cacheEntry struct {
+	key     string
+	scope   cacheScope
+	partial bool // results limited to selected fields
+	resp    *SearchResponse
+	expires time.Time
+}
+
+// searchCache is an LRU cache of search responses with a per-entry TTL.
+type searchCache struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // front is most recently used
+	stats   SearchCacheStats
+	// gen counts invalidations. A search records it on a miss and only
+	// caches its response if no write invalidated the cache meanwhile.
+	gen uint64
+}
+
+func newSearchCache(size int, ttl time.Duration) *searchCache {
+	return &searchCache{
+		size:    max(size, 1),
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// searchCacheKey returns the cache key for a request whose defaults have
+// been applied. Queries differing only in case or whitespace share a key.
+func searchCacheKey(req *SearchRequest) string {
+	norm := *req
+	norm.Query = strings.ToLower(strings.Join(strings.Fields(req.Query), " "))
+	data, _ := json.Marshal(norm)
+	return string(data)
+}
+
+// get returns the cached response for key. On a miss it returns the
+// generation to pass to put.
+func (c *searchCache) get(key string) (*SearchResponse, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		c.stats.Misses++
+		return nil, c.gen, false
+	}
+	e := el.Value.(*cacheEntry)
+	if c.ttl > 0 && !c.now().Before(e.expires) {
+		c.removeElement(el)
+		c.stats.Evictions++
+		c.stats.Misses++
+		return nil, c.gen, false
+	}
+	c.lru.MoveToFront(el)
+	c.stats.Hits++
+	return cloneSearchResponse(e.resp), c.gen, true
+}
+
+// put caches resp unless the cache was invalidated since gen was read, in
+// which case resp may predate a write.
+func (c *searchCache) put(key string, gen uint64, req *SearchRequest, resp *SearchResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if gen != c.gen {
+		return
+	}
+
+	e := &cacheEntry{
+		key: key,
+		scope: cacheScope{
+			userID:  req.Filters.scopeValue("user_id"),
+			agentID: req.Filters.scopeValue("agent_id"),
+		},
+		partial: len(req.Fields) > 0,
+		resp:    cloneSearchResponse(resp),
+		expires: c.now().Add(c.ttl),
+	}
+	if el, ok := c.entries[key]; ok {
+		el.Value = e
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(e)
+	for c.lru.Len() > c.size {
+		c.removeElement(c.lru.Back())
+		c.stats.Evictions++
+	}
+}
+
+// invalidate drops every entry for which drop returns true. Entries whose
+// results were limited to selected fields are always dropped, since their
+// results may lack the fields needed to decide.
+func (c *searchCache) invalidate(drop func(e *cacheEntry) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	for el := c.lru.Front(); el != nil; {
+		next := el.Next()
+		if e := el.Value.(*cacheEntry); e.partial || drop(e) {
+			c.removeElement(el)
+			c.stats.Invalidations++
+		}
+		el = next
+	}
+}
+
+// scopeOf looks up a memory's scope in the cached results.
+func (c *searchCache) scopeOf(id string) (cacheScope, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for el := c.lru.Front(); el != nil; el = el.Next() {
+		for _, m := range el.Value.(*cacheEntry).resp.Results {
+			if m.ID == id {
+				return cacheScope{userID: m.UserID, agentID: m.AgentID}, true
+			}
+		}
+	}
+	return cacheScope{}, false
+}
+
+// anyResult reports whether any cached result satisfies pred.
+func (e *cacheEntry) anyResult(pred func(m *Memory) bool) bool {
+	for i := range e.resp.Results {
+		if pred(&e.resp.Results[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *searchCache) purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	c.stats.Invalidations += uint64(c.lru.Len())
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+}
+
+func (c *searchCache) removeElement(el *list.Element) {
+	c.lru.Remove(el)
+	delete(c.entries, el.Value.(*cacheEntry).key)
+}
+
+func (c *searchCache) snapshot() SearchCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := c.stats
+	s.Entries = c.lru.Len()
+	return s
+}
+
+func cloneSearchResponse(r *SearchResponse) *SearchResponse {
+	out := &SearchResponse{Results: make([]Memory, len(r.Results))}
+	for i, m := range r.Results {
+		m.Categories = slices.Clone(m.Categories)
+		if m.Metadata != nil {
+			md := make(map[string]any, len(m.Metadata))
+			for k, v := range m.Metadata {
+				md[k] = v
+			}
+			m.Metadata = md
+		}
+		out.Results[i] = m
+	}
+	return out
+}
+
+// SearchCacheStats returns the search cache's counters, or zero values if
+// the cache is not enabled.
+func (c *Client) SearchCacheStats() SearchCacheStats {
+	if c.cache == nil {
+		return SearchCacheStats{}
+	}
+	return c.cache.snapshot()
+}
+
+// The invalidate* methods clear cached searches that a successful write may
+// have changed. They are no-ops without a cache.
+
+// invalidateAdded handles memories added in the given scope: they can only
+// appear in searches that scope, or that are unscoped.
+func (c *Client) invalidateAdded(userID, agentID string) {
+	if c.cache == nil {
+		return
+	}
+	w := cacheScope{userID: userID, agentID: agentID}
+	c.cache.invalidate(func(e *cacheEntry) bool { return e.scope.affects(w) })
+}
+
+// invalidateDeleted handles memories removed by ID. Removal only shrinks
+// result sets, so only searches that returned one of the memories change.
+func (c *Client) invalidateDeleted(ids ...string) {
+	if c.cache == nil {
+		return
+	}
+	c.cache.invalidate(func(e *cacheEntry) bool {
+		return e.anyResult(func(m *Memory) bool { return slices.Contains(ids, m.ID) })
+	})
+}
+
+// invalidateUpdated handles memories changed in place. Besides searches
+// that returned them, any search in a memory's scope may now match it. If
+// a scope is unknown the whole cache is cleared.
+func (c *Client) invalidateUpdated(updated ...Memory) {
+	if c.cache == nil {
+		return
+	}
+	scopes := make([]cacheScope, 0, len(updated))
+	ids := make([]string, 0, len(updated))
+	for _, m := range updated {
+		scope := cacheScope{userID: m.UserID, agentID: m.AgentID}
+		if scope == (cacheScope{}) {
+			var ok bool
+			if scope, ok = c.cache.scopeOf(m.ID); !ok {
+				c.cache.purge()
+				return
+			}
+		}
+		scopes = append(scopes, scope)
+		ids = append(ids, m.ID)
+	}
+	c.cache.invalidate(func(e *cacheEntry) bool {
+		for _, w := range scopes {
+			if e.scope.affects(w) {
+				return true
+			}
+		}
+		return e.anyResult(func(m *Memory) bool { return slices.Contains(ids, m.ID) })
+	})
+}
+
+// invalidateMatching handles memories removed by filter or entity: searches
+// constrained to the same scope, and searches that returned a removed
+// memory, are dropped.
+func (c *Client) invalidateMatching(w cacheScope, removed func(m *Memory) bool) {
+	if c.cache == nil {
+		return
+	}
+	c.cache.invalidate(func(e *cacheEntry) bool {
+		return e.scope.affects(w) || e.anyResult(removed)
+	})
+}
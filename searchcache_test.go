@@ -0,0 +1,209 @@
+package mem0
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newCacheTestClient returns a client with a search cache whose server
+// answers searches with one memory per request, owned by the filtered
+// user, and counts search requests.
+func newCacheTestClient(t *testing.T, size int, ttl time.Duration) (*Client, *atomic.Int32) {
+	t.Helper()
+	var searches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/memories/search/":
+			searches.Add(1)
+			var req SearchRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			user, _ := req.Filters["user_id"].(string)
+			json.NewEncoder(w).Encode([]Memory{{ID: "mem-" + user, Memory: "fact", UserID: user}})
+		case "/v1/memories/":
+			json.NewEncoder(w).Encode(AddMemoriesResponse{})
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL), WithSearchCache(size, ttl))
+	return client, &searches
+}
+
+func TestSearchCacheHit(t *testing.T) {
+	client, searches := newCacheTestClient(t, 10, time.Minute)
+	ctx := context.Background()
+
+	first, err := client.SearchUserMemories(ctx, "u1", "Hiking  trips")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first.Results[0].Memory = "mutated"
+
+	second, err := client.SearchUserMemories(ctx, "u1", " hiking trips")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := searches.Load(); n != 1 {
+		t.Errorf("expected 1 search request, got %d", n)
+	}
+	if second.Results[0].Memory != "fact" {
+		t.Errorf("expected cached result to be isolated from caller changes, got %q", second.Results[0].Memory)
+	}
+	stats := client.SearchCacheStats()
+	if stats.Hits != 1 || stats.Misses != 1 || stats.Entries != 1 {
+		t.Errorf("expected 1 hit, 1 miss and 1 entry, got %+v", stats)
+	}
+
+	client.SearchUserMemories(ctx, "u2", "hiking trips")
+	if n := searches.Load(); n != 2 {
+		t.Errorf("expected different filters to miss, got %d requests", n)
+	}
+}
+
+func TestSearchCacheExpiryAndEviction(t *testing.T) {
+	client, searches := newCacheTestClient(t, 2, time.Minute)
+	now := time.Now()
+	client.cache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	client.SearchUserMemories(ctx, "u1", "a")
+	now = now.Add(2 * time.Minute)
+	client.SearchUserMemories(ctx, "u1", "a")
+	if n := searches.Load(); n != 2 {
+		t.Errorf("expected expired entry to be refetched, got %d requests", n)
+	}
+
+	client.SearchUserMemories(ctx, "u1", "b")
+	client.SearchUserMemories(ctx, "u1", "c")
+	client.SearchUserMemories(ctx, "u1", "a")
+	if n := searches.Load(); n != 5 {
+		t.Errorf("expected least recently used entry to be evicted, got %d requests", n)
+	}
+
+	stats := client.SearchCacheStats()
+	if stats.Evictions != 3 || stats.Entries != 2 {
+		t.Errorf("expected 3 evictions and 2 entries, got %+v", stats)
+	}
+}
+
+func TestSearchCacheInvalidation(t *testing.T) {
+	client, searches := newCacheTestClient(t, 10, time.Minute)
+	ctx := context.Background()
+
+	warm := func() {
+		for _, u := range []string{"u1", "u2", "u3"} {
+			client.SearchUserMemories(ctx, u, "q")
+		}
+	}
+	expect := func(name string, want int32) {
+		t.Helper()
+		before := searches.Load()
+		warm()
+		if got := searches.Load() - before; got != want {
+			t.Errorf("%s: expected %d refetched searches, got %d", name, want, got)
+		}
+	}
+
+	warm()
+	if _, err := client.AddMemory(ctx, "new fact", WithUserID("u1")); err != nil {
+		t.Fatalf("AddMemory: unexpected error: %v", err)
+	}
+	expect("add for u1", 1)
+
+	client.DeleteMemory(ctx, "mem-u2")
+	expect("delete of a cached result", 1)
+
+	client.UpdateMemory(ctx, "mem-u3", &UpdateMemoryRequest{Text: "changed"})
+	expect("update of a cached result", 1)
+
+	client.UpdateMemory(ctx, "mem-unknown", &UpdateMemoryRequest{Text: "changed"})
+	expect("update of an unknown memory", 3)
+
+	client.DeleteUser(ctx, "u2")
+	expect("delete user", 1)
+
+	client.DeleteMemories(ctx, &DeleteMemoriesRequest{Filters: NewFilters().WithUserID("u3")})
+	expect("delete by filter", 1)
+
+	if got := client.SearchCacheStats().Invalidations; got != 8 {
+		t.Errorf("expected 8 invalidations, got %d", got)
+	}
+}
+
+func TestSearchCacheSkipsResultsRacingAWrite(t *testing.T) {
+	var searches atomic.Int32
+	received, unblock := make(chan struct{}, 1), make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/memories/search/" {
+			if searches.Add(1) == 1 {
+				received <- struct{}{}
+				<-unblock
+			}
+			json.NewEncoder(w).Encode([]Memory{{ID: "mem-1", Memory: "fact", UserID: "u1"}})
+			return
+		}
+		json.NewEncoder(w).Encode(AddMemoriesResponse{})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL), WithSearchCache(10, time.Minute))
+	ctx := context.Background()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.SearchUserMemories(ctx, "u1", "q")
+		done <- err
+	}()
+	<-received
+
+	// The write completes while the search is in flight, so the search's
+	// response predates it and must not be cached.
+	if _, err := client.AddMemory(ctx, "new fact", WithUserID("u1")); err != nil {
+		t.Fatalf("AddMemory: unexpected error: %v", err)
+	}
+	close(unblock)
+	if err := <-done; err != nil {
+		t.Fatalf("Search: unexpected error: %v", err)
+	}
+
+	client.SearchUserMemories(ctx, "u1", "q")
+	if n := searches.Load(); n != 2 {
+		t.Errorf("expected the second search to miss, got %d requests", n)
+	}
+}
+
+func TestSearchCacheUnconstrainedScopes(t *testing.T) {
+	client, searches := newCacheTestClient(t, 10, time.Minute)
+	ctx := context.Background()
+
+	filters := []Filters{
+		NewFilters().WithUserID(Wildcard),
+		NewFilters().WithUserID("u1").Or(NewFilters().WithUserID("u2")),
+		{"NOT": []Filters{{"user_id": "u1"}}},
+		{"user_id": map[string]any{"in": []string{"u1", "u9"}}},
+		NewFilters().WithAgentID("a1").Or(NewFilters().WithUserID("u9")).WithRunID("r1"),
+	}
+	warm := func() {
+		for _, f := range filters {
+			if _, err := client.Search(ctx, &SearchRequest{Query: "q", Filters: f}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	}
+
+	warm()
+	client.AddMemory(ctx, "new fact", WithUserID("u9"))
+	before := searches.Load()
+	warm()
+	if got := searches.Load() - before; got != int32(len(filters)) {
+		t.Errorf("expected every unconstrained search to be refetched, got %d of %d", got, len(filters))
+	}
+}
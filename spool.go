@@ -0,0 +1,136 @@
+package mem0
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/alcova-ai/mem0-go/internal/fsutil"
+)
+
+// spool is an append-only JSONL log of queued AddMemoriesRequests. Each
+// enqueue appends {"seq":n,"req":{...}} and each acknowledgement appends
+// {"seq":n,"ack":true}; entries without an ack are replayed on open.
+//
+// Every append is fsynced before it returns, so a queued request survives
+// a crash or power loss, and a request that was written, dropped or failed
+// is not replayed once its ack has returned.
+type spool struct {
+	mu   sync.Mutex
+	path string
+	f    *os.File
+}
+
+type spoolRecord struct {
+	Seq uint64              `json:"seq"`
+	Req *AddMemoriesRequest `json:"req,omitempty"`
+	Ack bool                `json:"ack,omitempty"`
+}
+
+type spoolEntry struct {
+	seq uint64
+	req *AddMemoriesRequest
+}
+
+// openSpool reads the spool at path, compacts it down to its
+// unacknowledged entries and opens it for appending. It returns the
+// pending entries in enqueue order.
+func openSpool(path string) (*spool, []spoolEntry, error) {
+	pending, err := readSpool(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Rewrite the spool with only the pending entries so it doesn't grow
+	// without bound across restarts.
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range pending {
+		enc.Encode(spoolRecord{Seq: e.seq, Req: e.req})
+	}
+	if err := fsutil.WriteAtomic(path, buf.Bytes()); err != nil {
+		return nil, nil, fmt.Errorf("mem0: compact spool: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, nil, fmt.Errorf("mem0: open spool: %w", err)
+	}
+	return &spool{path: path, f: f}, pending, nil
+}
+
+func readSpool(path string) ([]spoolEntry, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("mem0: read spool: %w", err)
+	}
+
+	pending := make(map[uint64]*AddMemoriesRequest)
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(nil, len(data)+1)
+	for sc.Scan() {
+		var rec spoolRecord
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			// A torn write from a crash; everything before it is intact.
+			continue
+		}
+		if rec.Ack {
+			delete(pending, rec.Seq)
+		} else if rec.Req != nil {
+			pending[rec.Seq] = rec.Req
+		}
+	}
+
+	entries := make([]spoolEntry, 0, len(pending))
+	for seq, req := range pending {
+		entries = append(entries, spoolEntry{seq: seq, req: req})
+	}
+	slices.SortFunc(entries, func(a, b spoolEntry) int {
+		return cmp.Compare(a.seq, b.seq)
+	})
+	return entries, nil
+}
+
+func (s *spool) append(rec spoolRecord) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("mem0: encode spool entry: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("mem0: write spool: %w", err)
+	}
+	if err := s.f.Sync(); err != nil {
+		return fmt.Errorf("mem0: sync spool: %w", err)
+	}
+	return nil
+}
+
+func (s *spool) add(e spoolEntry) error {
+	return s.append(spoolRecord{Seq: e.seq, Req: e.req})
+}
+
+func (s *spool) ack(seq uint64) error {
+	return s.append(spoolRecord{Seq: seq, Ack: true})
+}
+
+// close closes the spool, truncating it if nothing is pending.
+func (s *spool) close(empty bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if empty {
+		s.f.Truncate(0)
+	}
+	return s.f.Close()
+}
@@ -0,0 +1,25 @@
+package mem0
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out mem0mock/store.go -pkg mem0mock -rm . MemoryStore
+
+// MemoryStore is the set of memory operations implemented by *Client.
+// Depend on it instead of *Client to substitute a mock, a decorator or an
+// alternative backend.
+type MemoryStore interface {
+	AddMemories(ctx context.Context, req *AddMemoriesRequest) (*AddMemoriesResponse, error)
+	Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error)
+	GetMemory(ctx context.Context, memoryID string) (*Memory, error)
+	GetMemories(ctx context.Context, req *GetMemoriesRequest) (*GetMemoriesResponse, error)
+	UpdateMemory(ctx context.Context, memoryID string, req *UpdateMemoryRequest) (*Memory, error)
+	DeleteMemory(ctx context.Context, memoryID string) error
+	DeleteMemories(ctx context.Context, req *DeleteMemoriesRequest) error
+	GetMemoryHistory(ctx context.Context, memoryID string) ([]MemoryHistory, error)
+	BatchUpdate(ctx context.Context, req *BatchUpdateRequest) (*BatchResult, error)
+	BatchDelete(ctx context.Context, req *BatchDeleteRequest) (*BatchResult, error)
+	ListEntities(ctx context.Context, req *ListEntitiesRequest) (*ListEntitiesResponse, error)
+	DeleteEntity(ctx context.Context, entityType EntityType, entityID string) error
+}
+
+var _ MemoryStore = (*Client)(nil)
@@ -0,0 +1,165 @@
+package mem0
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+const instrumentationName = "github.com/alcova-ai/mem0-go"
+
+// telemetry emits one span and one duration measurement per client
+// operation. Retries are recorded as span events rather than child spans.
+type telemetry struct {
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+	errors   metric.Int64Counter
+}
+
+func newTelemetry(tp trace.TracerProvider, mp metric.MeterProvider) *telemetry {
+	if tp == nil {
+		tp = tracenoop.NewTracerProvider()
+	}
+	if mp == nil {
+		mp = metricnoop.NewMeterProvider()
+	}
+
+	meter := mp.Meter(instrumentationName)
+	t := &telemetry{tracer: tp.Tracer(instrumentationName)}
+
+	// Instrument creation only fails for invalid names; fall back to no-ops
+	// rather than failing NewClient.
+	var err error
+	t.duration, err = meter.Float64Histogram("mem0.client.operation.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of mem0 client operations, including retries."))
+	if err != nil {
+		t.duration, _ = metricnoop.Meter{}.Float64Histogram("")
+	}
+	t.errors, err = meter.Int64Counter("mem0.client.operation.errors",
+		metric.WithDescription("Failed mem0 client operations by error code."))
+	if err != nil {
+		t.errors, _ = metricnoop.Meter{}.Int64Counter("")
+	}
+
+	return t
+}
+
+// start opens the span for an operation. The returned function ends it,
+// recording the result count from out and the final error.
+func (t *telemetry) start(ctx context.Context, op, method, path string, body any) (context.Context, func(out any, err error)) {
+	attrs := []attribute.KeyValue{
+		attribute.String("mem0.operation", op),
+		attribute.String("http.request.method", method),
+		attribute.String("url.path", path),
+	}
+	attrs = append(attrs, requestAttributes(body)...)
+
+	ctx, span := t.tracer.Start(ctx, "mem0."+op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+	start := time.Now()
+
+	return ctx, func(out any, err error) {
+		opAttr := attribute.String("mem0.operation", op)
+		t.duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(opAttr))
+
+		if err != nil {
+			t.errors.Add(ctx, 1, metric.WithAttributes(opAttr, attribute.String("error.code", errorCode(err))))
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		} else if n, ok := resultCount(out); ok {
+			span.SetAttributes(attribute.Int("mem0.result_count", n))
+		}
+		span.End()
+	}
+}
+
+// recordAttempt annotates the operation's span with the outcome of one
+// HTTP attempt.
+func (t *telemetry) recordAttempt(ctx context.Context, attempt int, call *Call) {
+	span := trace.SpanFromContext(ctx)
+	if call.HTTPResponse != nil {
+		span.SetAttributes(attribute.Int("http.response.status_code", call.HTTPResponse.StatusCode))
+	}
+	if attempt > 0 {
+		span.AddEvent("retry", trace.WithAttributes(attribute.Int("mem0.attempt", attempt+1)))
+		span.SetAttributes(attribute.Int("mem0.retries", attempt))
+	}
+}
+
+func requestAttributes(body any) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	addScope := func(userID, agentID string) {
+		if userID != "" {
+			attrs = append(attrs, attribute.String("mem0.user_id", userID))
+		}
+		if agentID != "" {
+			attrs = append(attrs, attribute.String("mem0.agent_id", agentID))
+		}
+	}
+
+	switch r := body.(type) {
+	case *AddMemoriesRequest:
+		addScope(r.UserID, r.AgentID)
+		attrs = append(attrs, attribute.Int("mem0.message_count", len(r.Messages)))
+	case *SearchRequest:
+		addScope(r.Filters.scopeValue("user_id"), r.Filters.scopeValue("agent_id"))
+		if r.TopK > 0 {
+			attrs = append(attrs, attribute.Int("mem0.top_k", r.TopK))
+		}
+	case *GetMemoriesRequest:
+		addScope(r.Filters.scopeValue("user_id"), r.Filters.scopeValue("agent_id"))
+	case *DeleteMemoriesRequest:
+		addScope(r.Filters.scopeValue("user_id"), r.Filters.scopeValue("agent_id"))
+	case *BatchUpdateRequest:
+		attrs = append(attrs, attribute.Int("mem0.batch_size", len(r.Memories)))
+	case *BatchDeleteRequest:
+		attrs = append(attrs, attribute.Int("mem0.batch_size", len(r.MemoryIDs)))
+	}
+	return attrs
+}
+
+func resultCount(out any) (int, bool) {
+	switch r := out.(type) {
+	case *SearchResponse:
+		return len(r.Results), true
+	case *GetMemoriesResponse:
+		return len(r.Results), true
+	case *[]Memory:
+		return len(*r), true
+	case *AddMemoriesResponse:
+		return len(r.Results), true
+	case *ListEntitiesResponse:
+		return len(r.Results), true
+	case *[]MemoryHistory:
+		return len(*r), true
+	}
+	return 0, false
+}
+
+// errorCode classifies err for the error counter: the API's error code when
+// present, otherwise the HTTP status or a coarse category.
+func errorCode(err error) string {
+	var apiErr *APIError
+	switch {
+	case errors.As(err, &apiErr):
+		if apiErr.Code != "" {
+			return apiErr.Code
+		}
+		return strconv.Itoa(apiErr.StatusCode)
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "deadline_exceeded"
+	}
+	return "transport"
+}
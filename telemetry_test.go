@@ -0,0 +1,161 @@
+package mem0
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingSpanPerOperation(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode([]Memory{{ID: "mem-1"}, {ID: "mem-2"}})
+	}))
+	defer server.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	client, _ := NewClient("test-key",
+		WithBaseURL(server.URL),
+		WithTracerProvider(tp),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 1, InitialBackoff: time.Millisecond, RetryPOST: true}),
+	)
+
+	_, err := client.SearchUserMemories(context.Background(), "user-1", "food", WithTopK(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "mem0.Search" {
+		t.Errorf("expected span name 'mem0.Search', got %q", span.Name)
+	}
+
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	if v := attrs["mem0.user_id"].AsString(); v != "user-1" {
+		t.Errorf("expected mem0.user_id 'user-1', got %q", v)
+	}
+	if v := attrs["mem0.top_k"].AsInt64(); v != 3 {
+		t.Errorf("expected mem0.top_k 3, got %d", v)
+	}
+	if v := attrs["mem0.result_count"].AsInt64(); v != 2 {
+		t.Errorf("expected mem0.result_count 2, got %d", v)
+	}
+	if v := attrs["http.response.status_code"].AsInt64(); v != http.StatusOK {
+		t.Errorf("expected final status 200, got %d", v)
+	}
+	if v := attrs["mem0.retries"].AsInt64(); v != 1 {
+		t.Errorf("expected mem0.retries 1, got %d", v)
+	}
+}
+
+func TestTracingRecordsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(APIError{Code: "memory_not_found"})
+	}))
+	defer server.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	client, _ := NewClient("test-key", WithBaseURL(server.URL), WithTracerProvider(tp))
+
+	if _, err := client.GetMemory(context.Background(), "mem-1"); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Status.Code != codes.Error {
+		t.Errorf("expected error status, got %v", spans[0].Status.Code)
+	}
+	if code := errorCode(&APIError{StatusCode: 404, Code: "memory_not_found"}); code != "memory_not_found" {
+		t.Errorf("expected error code 'memory_not_found', got %q", code)
+	}
+}
+
+func TestMetricsDurationAndErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/memories/mem-1/":
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(APIError{Code: "memory_not_found"})
+		case "/v1/memories/mem-2/":
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			json.NewEncoder(w).Encode(Memory{ID: "mem-3"})
+		}
+	}))
+	defer server.Close()
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	client, _ := NewClient("test-key", WithBaseURL(server.URL), WithMeterProvider(mp))
+
+	ctx := context.Background()
+	for _, id := range []string{"mem-1", "mem-2", "mem-3"} {
+		client.GetMemory(ctx, id)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	metrics := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+
+	duration, ok := metrics["mem0.client.operation.duration"].(metricdata.Histogram[float64])
+	if !ok || len(duration.DataPoints) != 1 {
+		t.Fatalf("expected one duration histogram series, got %+v", metrics["mem0.client.operation.duration"])
+	}
+	if dp := duration.DataPoints[0]; dp.Count != 3 {
+		t.Errorf("expected 3 duration measurements, got %d", dp.Count)
+	} else if op, _ := dp.Attributes.Value("mem0.operation"); op.AsString() != "GetMemory" {
+		t.Errorf("expected mem0.operation 'GetMemory', got %q", op.AsString())
+	}
+
+	errs, ok := metrics["mem0.client.operation.errors"].(metricdata.Sum[int64])
+	if !ok {
+		t.Fatalf("expected error counter, got %+v", metrics["mem0.client.operation.errors"])
+	}
+	got := map[string]int64{}
+	for _, dp := range errs.DataPoints {
+		code, _ := dp.Attributes.Value("error.code")
+		got[code.AsString()] += dp.Value
+	}
+	want := map[string]int64{"memory_not_found": 1, "400": 1}
+	if !maps.Equal(got, want) {
+		t.Errorf("expected errors %v, got %v", want, got)
+	}
+}
@@ -1,6 +1,9 @@
 package mem0
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 type Memory struct {
 	ID             string         `json:"id"`
@@ -26,7 +29,7 @@ type MemoryHistory struct {
 	MemoryID  string         `json:"memory_id"`
 	OldMemory string         `json:"old_memory,omitempty"`
 	NewMemory string         `json:"new_memory"`
-	Event     string         `json:"event"`
+	Event     HistoryEvent   `json:"event"`
 	UserID    string         `json:"user_id,omitempty"`
 	Input     []Message      `json:"input,omitempty"`
 	Metadata  map[string]any `json:"metadata,omitempty"`
@@ -76,53 +79,35 @@ func NewFilters() Filters {
 }
 
 func (f Filters) WithUserID(id string) Filters {
-	f["user_id"] = id
-	return f
+	return f.set("user_id", id)
 }
 
 func (f Filters) WithAgentID(id string) Filters {
-	f["agent_id"] = id
-	return f
+	return f.set("agent_id", id)
 }
 
 func (f Filters) WithAppID(id string) Filters {
-	f["app_id"] = id
-	return f
+	return f.set("app_id", id)
 }
 
 func (f Filters) WithRunID(id string) Filters {
-	f["run_id"] = id
-	return f
+	return f.set("run_id", id)
 }
 
 func (f Filters) WithCategories(categories ...string) Filters {
-	f["categories"] = map[string]any{"in": categories}
-	return f
+	return f.set("categories", map[string]any{"in": categories})
 }
 
 func (f Filters) WithCategoryContains(category string) Filters {
-	f["categories"] = map[string]any{"contains": category}
-	return f
+	return f.set("categories", map[string]any{"contains": category})
 }
 
 func (f Filters) WithCreatedAfter(t time.Time) Filters {
-	m, _ := f["created_at"].(map[string]any)
-	if m == nil {
-		m = map[string]any{}
-		f["created_at"] = m
-	}
-	m["gte"] = t.Format(time.RFC3339)
-	return f
+	return f.setRange("gte", t)
 }
 
 func (f Filters) WithCreatedBefore(t time.Time) Filters {
-	m, _ := f["created_at"].(map[string]any)
-	if m == nil {
-		m = map[string]any{}
-		f["created_at"] = m
-	}
-	m["lte"] = t.Format(time.RFC3339)
-	return f
+	return f.setRange("lte", t)
 }
 
 func (f Filters) And(filters ...Filters) Filters {
@@ -138,3 +123,108 @@ func (f Filters) Or(filters ...Filters) Filters {
 	parts = append(parts, filters...)
 	return Filters{"OR": parts}
 }
+
+// set adds a field condition. If f is a logical group (after And, Or or
+// Not), the condition is ANDed with the group rather than written as a
+// sibling key, which the platform would reject or misread.
+func (f Filters) set(key string, value any) Filters {
+	if !f.logical() {
+		f[key] = value
+		return f
+	}
+	f.andWith(Filters{key: value})
+	return f
+}
+
+func (f Filters) setRange(op string, t time.Time) Filters {
+	if f.logical() {
+		f.andWith(Filters{"created_at": map[string]any{op: t.Format(time.RFC3339)}})
+		return f
+	}
+	m, _ := f["created_at"].(map[string]any)
+	if m == nil {
+		m = map[string]any{}
+		f["created_at"] = m
+	}
+	m[op] = t.Format(time.RFC3339)
+	return f
+}
+
+// logical reports whether f has a logical operator at its root.
+func (f Filters) logical() bool {
+	_, and := f["AND"]
+	_, or := f["OR"]
+	_, not := f["NOT"]
+	return and || or || not
+}
+
+// andWith ANDs cond with f in place. An existing top-level AND group is
+// extended; any other root is wrapped. The group is extended into a new
+// slice, so shallow copies of f made with maps.Clone are not affected.
+func (f Filters) andWith(cond Filters) {
+	if and, ok := f["AND"].([]Filters); ok && len(f) == 1 {
+		f["AND"] = append(slices.Clip(and), cond)
+		return
+	}
+	root := make(Filters, len(f))
+	for k, v := range f {
+		root[k] = v
+		delete(f, k)
+	}
+	f["AND"] = []Filters{root, cond}
+}
+
+// clone returns a deep copy of f, so that the copy can be modified in
+// place without affecting f or other copies.
+func (f Filters) clone() Filters {
+	if f == nil {
+		return nil
+	}
+	out := make(Filters, len(f))
+	for k, v := range f {
+		out[k] = cloneFilterValue(v)
+	}
+	return out
+}
+
+func cloneFilterValue(v any) any {
+	switch v := v.(type) {
+	case Filters:
+		return v.clone()
+	case map[string]any:
+		return map[string]any(Filters(v).clone())
+	case []Filters:
+		out := make([]Filters, len(v))
+		for i, sub := range v {
+			out[i] = sub.clone()
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = cloneFilterValue(e)
+		}
+		return out
+	case []string:
+		return slices.Clone(v)
+	}
+	return v
+}
+
+// scopeValue returns the ID that key must equal for a memory to match f,
+// looking at the top level and inside AND groups, or "" if key is not
+// constrained to one ID. The wildcard and operators such as "in" or "ne"
+// match several IDs, and conditions under OR or NOT do not constrain
+// every match, so none of them count.
+func (f Filters) scopeValue(key string) string {
+	if v, ok := f[key].(string); ok && v != Wildcard {
+		return v
+	}
+	and, _ := f["AND"].([]Filters)
+	for _, sub := range and {
+		if v := sub.scopeValue(key); v != "" {
+			return v
+		}
+	}
+	return ""
+}